@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/sukhera/APIWeaver/internal/config"
+	"github.com/sukhera/APIWeaver/internal/domain/generator"
+	"github.com/sukhera/APIWeaver/internal/logger"
+	"github.com/sukhera/APIWeaver/internal/services"
+)
+
+// NewExportCmd creates the export command
+func NewExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export parts of a Markdown specification in other formats",
+		Long: `Export parts of a structured Markdown file in formats other than OpenAPI,
+such as the components as standalone JSON Schema documents.`,
+	}
+
+	cmd.AddCommand(newExportJSONSchemaCmd())
+
+	return cmd
+}
+
+// newExportJSONSchemaCmd creates the export jsonschema command
+func newExportJSONSchemaCmd() *cobra.Command {
+	var (
+		outputDir  string
+		baseID     string
+		bundle     string
+		configFile string
+		verbose    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "jsonschema [input-file]",
+		Short: "Export components as JSON Schema 2020-12 files",
+		Long: `Export every component of a structured Markdown file as a standalone JSON Schema 2020-12
+document, one file per component, addressed by "$id". References between components
+become relative references to the other files. With --bundle, a single file additionally
+holds every component under "$defs".`,
+		Args: cobra.ExactArgs(1),
+		Example: `  apiweaver export jsonschema api-docs.md --output-dir schemas
+  apiweaver export jsonschema api-docs.md --base-id https://example.com/schemas/
+  apiweaver export jsonschema api-docs.md --bundle bundle.schema.json --verbose`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExportJSONSchema(cmd.Context(), args[0], outputDir, baseID, bundle, configFile, verbose)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputDir, "output-dir", "o", ".", "Directory to write the schema files to")
+	cmd.Flags().StringVar(&baseID, "base-id", "", "Base URI of the schema ids (ids are relative file names if not specified)")
+	cmd.Flags().StringVar(&bundle, "bundle", "", "File name of an additional bundle holding every component under $defs")
+	cmd.Flags().StringVarP(&configFile, "config", "c", "", "Configuration file path")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
+
+	return cmd
+}
+
+func runExportJSONSchema(ctx context.Context, inputFile, outputDir, baseID, bundle, configFile string, verbose bool) error {
+	// Load configuration
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Override with command line flags
+	if verbose {
+		cfg.Verbose = true
+	}
+	if bundle != "" && filepath.Base(bundle) != bundle {
+		return fmt.Errorf("invalid --bundle: %q must be a file name, use --output-dir for its directory", bundle)
+	}
+
+	// Setup logger
+	log, err := logger.New(cfg.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	log.Info("Starting JSON Schema export",
+		"input_file", inputFile,
+		"output_dir", outputDir,
+	)
+
+	// Clean and validate input file path
+	inputFile = filepath.Clean(inputFile)
+
+	// Read input file
+	content, err := os.ReadFile(inputFile) // #nosec G304 - file path is from CLI argument
+	if err != nil {
+		return fmt.Errorf("failed to read input file %s: %w", inputFile, err)
+	}
+
+	// Create generator service
+	generatorService := services.NewGenerator(cfg, log)
+
+	// Export the components
+	result, err := generatorService.ExportJSONSchemas(ctx, string(content), generator.JSONSchemaConfig{
+		BaseID: baseID,
+		Bundle: bundle,
+	})
+	if err != nil {
+		log.Error("Export failed", "error", err)
+		return fmt.Errorf("failed to export JSON Schemas: %w", err)
+	}
+
+	// Write the files
+	outputDir = filepath.Clean(outputDir)
+	if err := os.MkdirAll(outputDir, 0750); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
+	}
+	for _, file := range result.Files {
+		path := filepath.Join(outputDir, file.Name)
+		if err := os.WriteFile(path, []byte(file.Content+"\n"), 0600); err != nil {
+			return fmt.Errorf("failed to write output file %s: %w", path, err)
+		}
+	}
+	log.Info("JSON Schemas exported successfully", "output_dir", outputDir, "files", len(result.Files))
+
+	// Print summary
+	if verbose {
+		fmt.Fprintf(os.Stderr, "\nExport Summary:\n")
+		fmt.Fprintf(os.Stderr, "  Files: %d\n", len(result.Files))
+		for _, file := range result.Files {
+			fmt.Fprintf(os.Stderr, "    - %s (%s)\n", file.Name, file.ID)
+		}
+		if len(result.Warnings) > 0 {
+			fmt.Fprintf(os.Stderr, "  Warnings: %d\n", len(result.Warnings))
+			for _, warning := range result.Warnings {
+				fmt.Fprintf(os.Stderr, "    - %s\n", warning)
+			}
+		}
+	}
+
+	return nil
+}
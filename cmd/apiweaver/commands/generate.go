@@ -18,6 +18,9 @@ func NewGenerateCmd() *cobra.Command {
 		outputFile   string
 		outputFormat string
 		configFile   string
+		pathOrder    string
+		target       string
+		hoistSchemas bool
 		verbose      bool
 	)
 
@@ -30,21 +33,27 @@ with endpoints, parameters, and response definitions.`,
 		Args: cobra.ExactArgs(1),
 		Example: `  apiweaver generate api-docs.md
   apiweaver generate docs.md --output openapi.yaml --format yaml
-  apiweaver generate example.md --config config.yaml --verbose`,
+  apiweaver generate example.md --config config.yaml --verbose
+  apiweaver generate docs.md --path-order sorted
+  apiweaver generate docs.md --hoist-schemas --verbose
+  apiweaver generate docs.md --target 2.0 --output swagger.json --format json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGenerate(cmd.Context(), args[0], outputFile, outputFormat, configFile, verbose)
+			return runGenerate(cmd.Context(), args[0], outputFile, outputFormat, configFile, pathOrder, target, hoistSchemas, verbose)
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file for generated OpenAPI spec")
 	cmd.Flags().StringVarP(&outputFormat, "format", "f", "yaml", "Output format (yaml, json)")
 	cmd.Flags().StringVarP(&configFile, "config", "c", "", "Configuration file path")
+	cmd.Flags().StringVar(&pathOrder, "path-order", "", "Order of generated paths (source, sorted); overrides the configuration")
+	cmd.Flags().StringVar(&target, "target", "", "Specification version to generate (3.1, 3.0, 2.0); overrides the configuration")
+	cmd.Flags().BoolVar(&hoistSchemas, "hoist-schemas", false, "Move repeated inline object schemas into components")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
 
 	return cmd
 }
 
-func runGenerate(ctx context.Context, inputFile, outputFile, outputFormat, configFile string, verbose bool) error {
+func runGenerate(ctx context.Context, inputFile, outputFile, outputFormat, configFile, pathOrder, target string, hoistSchemas, verbose bool) error {
 	// Load configuration
 	cfg, err := config.Load(configFile)
 	if err != nil {
@@ -55,6 +64,21 @@ func runGenerate(ctx context.Context, inputFile, outputFile, outputFormat, confi
 	if verbose {
 		cfg.Verbose = true
 	}
+	if hoistSchemas {
+		cfg.HoistSchemas = true
+	}
+	if pathOrder != "" {
+		cfg.PathOrder = pathOrder
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid --path-order: %w", err)
+		}
+	}
+	if target != "" {
+		cfg.Target = target
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid --target: %w", err)
+		}
+	}
 
 	// Setup logger
 	log, err := logger.New(cfg.Logger)
@@ -105,6 +129,12 @@ func runGenerate(ctx context.Context, inputFile, outputFile, outputFormat, confi
 		fmt.Fprintf(os.Stderr, "  Processing time: %dms\n", spec.Metadata.ProcessingTimeMs)
 		if len(spec.Warnings) > 0 {
 			fmt.Fprintf(os.Stderr, "  Warnings: %d\n", len(spec.Warnings))
+			for _, warning := range spec.Warnings {
+				fmt.Fprintf(os.Stderr, "    - %s\n", warning)
+			}
+		}
+		for _, hoisted := range spec.Hoisted {
+			fmt.Fprintf(os.Stderr, "  Hoisted schema %s: %d references\n", hoisted.Name, len(hoisted.Locations))
 		}
 	}
 
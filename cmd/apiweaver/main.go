@@ -31,6 +31,7 @@ func main() {
 	rootCmd.AddCommand(commands.NewAmendCmd())
 	rootCmd.AddCommand(commands.NewValidateCmd())
 	rootCmd.AddCommand(commands.NewServeCmd())
+	rootCmd.AddCommand(commands.NewExportCmd())
 
 	// Execute the root command
 	if err := rootCmd.Execute(); err != nil {
@@ -23,6 +23,9 @@ type Config struct {
 	RequireExamples bool     `mapstructure:"require_examples" json:"require_examples"`
 	MaxNestingDepth int      `mapstructure:"max_nesting_depth" json:"max_nesting_depth"`
 
+	// Naming settings
+	OperationIDStyle string `mapstructure:"operation_id_style" json:"operation_id_style"` // "camel" or "snake"
+
 	// Logging and monitoring
 	Verbose         bool `mapstructure:"verbose" json:"verbose"`
 	EnableMetrics   bool `mapstructure:"enable_metrics" json:"enable_metrics"`
@@ -31,6 +34,9 @@ type Config struct {
 	// Output settings
 	OutputFormat string `mapstructure:"output_format" json:"output_format"`
 	PrettyPrint  bool   `mapstructure:"pretty_print" json:"pretty_print"`
+	PathOrder    string `mapstructure:"path_order" json:"path_order"` // "source" or "sorted"
+	HoistSchemas bool   `mapstructure:"hoist_schemas" json:"hoist_schemas"`
+	Target       string `mapstructure:"target" json:"target"` // "3.1", "3.0" or "2.0"
 }
 
 // NewViperConfig creates a new Viper instance with default configuration
@@ -47,11 +53,15 @@ func NewViperConfig() *viper.Viper {
 	v.SetDefault("allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"})
 	v.SetDefault("require_examples", false)
 	v.SetDefault("max_nesting_depth", 10)
+	v.SetDefault("operation_id_style", "camel")
 	v.SetDefault("verbose", false)
 	v.SetDefault("enable_metrics", false)
 	v.SetDefault("enable_profiling", false)
 	v.SetDefault("output_format", "json")
 	v.SetDefault("pretty_print", true)
+	v.SetDefault("path_order", "source")
+	v.SetDefault("hoist_schemas", false)
+	v.SetDefault("target", "3.1")
 
 	// Configure Viper
 	v.SetConfigName("apiweaver")        // name of config file (without extension)
@@ -98,11 +108,15 @@ func Default() *Config {
 		AllowedMethods:       []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
 		RequireExamples:      false,
 		MaxNestingDepth:      10,
+		OperationIDStyle:     "camel",
 		Verbose:              false,
 		EnableMetrics:        false,
 		EnableProfiling:      false,
 		OutputFormat:         "json",
 		PrettyPrint:          true,
+		PathOrder:            "source",
+		HoistSchemas:         false,
+		Target:               "3.1",
 	}
 }
 
@@ -133,11 +147,15 @@ func (c *Config) SaveToFile(filename string) error {
 	v.Set("allowed_methods", c.AllowedMethods)
 	v.Set("require_examples", c.RequireExamples)
 	v.Set("max_nesting_depth", c.MaxNestingDepth)
+	v.Set("operation_id_style", c.OperationIDStyle)
 	v.Set("verbose", c.Verbose)
 	v.Set("enable_metrics", c.EnableMetrics)
 	v.Set("enable_profiling", c.EnableProfiling)
 	v.Set("output_format", c.OutputFormat)
 	v.Set("pretty_print", c.PrettyPrint)
+	v.Set("path_order", c.PathOrder)
+	v.Set("hoist_schemas", c.HoistSchemas)
+	v.Set("target", c.Target)
 
 	// Set config file
 	v.SetConfigFile(filename)
@@ -185,6 +203,18 @@ func (c *Config) Validate() error {
 		return errors.NewConfigError("at least one HTTP method must be allowed")
 	}
 
+	validStyles := []string{"camel", "snake"}
+	valid = false
+	for _, style := range validStyles {
+		if c.OperationIDStyle == style {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return errors.NewConfigError(fmt.Sprintf("operation_id_style must be one of: %v", validStyles))
+	}
+
 	validFormats := []string{"json", "yaml", "text"}
 	valid = false
 	for _, format := range validFormats {
@@ -197,6 +227,30 @@ func (c *Config) Validate() error {
 		return errors.NewConfigError(fmt.Sprintf("output_format must be one of: %v", validFormats))
 	}
 
+	validOrders := []string{"source", "sorted"}
+	valid = false
+	for _, order := range validOrders {
+		if c.PathOrder == order {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return errors.NewConfigError(fmt.Sprintf("path_order must be one of: %v", validOrders))
+	}
+
+	validTargets := []string{"3.1", "3.0", "2.0"}
+	valid = false
+	for _, target := range validTargets {
+		if c.Target == target {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return errors.NewConfigError(fmt.Sprintf("target must be one of: %v", validTargets))
+	}
+
 	return nil
 }
 
@@ -213,11 +267,15 @@ func (c *Config) ToViper() *viper.Viper {
 	v.Set("allowed_methods", c.AllowedMethods)
 	v.Set("require_examples", c.RequireExamples)
 	v.Set("max_nesting_depth", c.MaxNestingDepth)
+	v.Set("operation_id_style", c.OperationIDStyle)
 	v.Set("verbose", c.Verbose)
 	v.Set("enable_metrics", c.EnableMetrics)
 	v.Set("enable_profiling", c.EnableProfiling)
 	v.Set("output_format", c.OutputFormat)
 	v.Set("pretty_print", c.PrettyPrint)
+	v.Set("path_order", c.PathOrder)
+	v.Set("hoist_schemas", c.HoistSchemas)
+	v.Set("target", c.Target)
 
 	return v
 }
@@ -23,11 +23,15 @@ func TestConfig_Default(t *testing.T) {
 	assert.Len(t, cfg.AllowedMethods, 7)
 	assert.False(t, cfg.RequireExamples)
 	assert.Equal(t, 10, cfg.MaxNestingDepth)
+	assert.Equal(t, "camel", cfg.OperationIDStyle)
 	assert.False(t, cfg.Verbose)
 	assert.False(t, cfg.EnableMetrics)
 	assert.False(t, cfg.EnableProfiling)
 	assert.Equal(t, "json", cfg.OutputFormat)
 	assert.True(t, cfg.PrettyPrint)
+	assert.Equal(t, "source", cfg.PathOrder)
+	assert.False(t, cfg.HoistSchemas)
+	assert.Equal(t, "3.1", cfg.Target)
 }
 
 func TestConfig_Validate(t *testing.T) {
@@ -95,6 +99,15 @@ func TestConfig_Validate(t *testing.T) {
 			}(),
 			wantErr: true,
 		},
+		{
+			name: "invalid operation id style",
+			config: func() *Config {
+				cfg := Default()
+				cfg.OperationIDStyle = "kebab"
+				return cfg
+			}(),
+			wantErr: true,
+		},
 		{
 			name: "invalid output format",
 			config: func() *Config {
@@ -104,6 +117,24 @@ func TestConfig_Validate(t *testing.T) {
 			}(),
 			wantErr: true,
 		},
+		{
+			name: "invalid path order",
+			config: func() *Config {
+				cfg := Default()
+				cfg.PathOrder = "alphabetical"
+				return cfg
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "invalid target",
+			config: func() *Config {
+				cfg := Default()
+				cfg.Target = "3.2"
+				return cfg
+			}(),
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
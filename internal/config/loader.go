@@ -131,11 +131,15 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"})
 	v.SetDefault("require_examples", false)
 	v.SetDefault("max_nesting_depth", 10)
+	v.SetDefault("operation_id_style", "camel")
 	v.SetDefault("verbose", false)
 	v.SetDefault("enable_metrics", false)
 	v.SetDefault("enable_profiling", false)
 	v.SetDefault("output_format", "yaml")
 	v.SetDefault("pretty_print", true)
+	v.SetDefault("path_order", "source")
+	v.SetDefault("hoist_schemas", false)
+	v.SetDefault("target", "3.1")
 
 	// Server defaults
 	v.SetDefault("server.port", 8080)
@@ -180,11 +184,15 @@ func (c *ExtendedConfig) Save(filename string) error {
 	v.Set("allowed_methods", c.AllowedMethods)
 	v.Set("require_examples", c.RequireExamples)
 	v.Set("max_nesting_depth", c.MaxNestingDepth)
+	v.Set("operation_id_style", c.OperationIDStyle)
 	v.Set("verbose", c.Verbose)
 	v.Set("enable_metrics", c.EnableMetrics)
 	v.Set("enable_profiling", c.EnableProfiling)
 	v.Set("output_format", c.OutputFormat)
 	v.Set("pretty_print", c.PrettyPrint)
+	v.Set("path_order", c.PathOrder)
+	v.Set("hoist_schemas", c.HoistSchemas)
+	v.Set("target", c.Target)
 
 	// Server config
 	v.Set("server", c.Server)
@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"strings"
 	"time"
 
 	"github.com/sukhera/APIWeaver/internal/domain/parser"
@@ -47,6 +48,24 @@ func (b *DocumentBuilder) AddEndpoints(endpoints []*parser.Endpoint) *DocumentBu
 	return b
 }
 
+// AddWebhook adds a webhook sent with the given operation. The operation path is ignored.
+func (b *DocumentBuilder) AddWebhook(name string, operation *parser.Endpoint) *DocumentBuilder {
+	if name != "" && operation != nil {
+		b.document.Webhooks = append(b.document.Webhooks, &parser.Webhook{
+			Name:       name,
+			Operation:  operation,
+			LineNumber: operation.LineNumber,
+		})
+	}
+	return b
+}
+
+// WithExtension sets a document-level vendor extension. Names without an "x-" prefix are given one.
+func (b *DocumentBuilder) WithExtension(name string, value interface{}) *DocumentBuilder {
+	b.document.Extensions = withExtension(b.document.Extensions, name, value)
+	return b
+}
+
 // AddComponent adds a reusable component
 func (b *DocumentBuilder) AddComponent(component *parser.Component) *DocumentBuilder {
 	if component != nil {
@@ -63,6 +82,14 @@ func (b *DocumentBuilder) AddComponents(components []*parser.Component) *Documen
 	return b
 }
 
+// AddTag adds a tag with its description
+func (b *DocumentBuilder) AddTag(name, description string) *DocumentBuilder {
+	if name != "" {
+		b.document.Tags = append(b.document.Tags, &parser.Tag{Name: name, Description: description})
+	}
+	return b
+}
+
 // AddError adds a parse error
 func (b *DocumentBuilder) AddError(err *errors.ParseError) *DocumentBuilder {
 	if err != nil {
@@ -130,6 +157,12 @@ func (b *EndpointBuilder) WithSummary(summary string) *EndpointBuilder {
 	return b
 }
 
+// WithOperationID sets the endpoint operation ID
+func (b *EndpointBuilder) WithOperationID(operationID string) *EndpointBuilder {
+	b.endpoint.OperationID = operationID
+	return b
+}
+
 // WithDescription sets the endpoint description
 func (b *EndpointBuilder) WithDescription(description string) *EndpointBuilder {
 	b.endpoint.Description = description
@@ -190,6 +223,31 @@ func (b *EndpointBuilder) AddTags(tags []string) *EndpointBuilder {
 	return b
 }
 
+// AddCallback adds a callback request sent to the URL expression with the given operation
+func (b *EndpointBuilder) AddCallback(name, expression string, operation *parser.Endpoint) *EndpointBuilder {
+	if name != "" && operation != nil {
+		b.endpoint.Callbacks = append(b.endpoint.Callbacks, &parser.Callback{
+			Name:       name,
+			Expression: expression,
+			Operation:  operation,
+			LineNumber: operation.LineNumber,
+		})
+	}
+	return b
+}
+
+// Deprecated marks the endpoint as deprecated with optional sunset date (YYYY-MM-DD) and replacement
+func (b *EndpointBuilder) Deprecated(since, sunset, replacedBy string) *EndpointBuilder {
+	b.endpoint.Deprecated = &parser.Deprecation{Since: since, Sunset: sunset, ReplacedBy: replacedBy, LineNumber: b.endpoint.LineNumber}
+	return b
+}
+
+// WithExtension sets a vendor extension of the operation. Names without an "x-" prefix are given one.
+func (b *EndpointBuilder) WithExtension(name string, value interface{}) *EndpointBuilder {
+	b.endpoint.Extensions = withExtension(b.endpoint.Extensions, name, value)
+	return b
+}
+
 // Build constructs the final Endpoint
 func (b *EndpointBuilder) Build() *parser.Endpoint {
 	return b.endpoint
@@ -248,7 +306,34 @@ func (b *ParameterBuilder) WithSchema(schema *parser.Schema) *ParameterBuilder {
 	return b
 }
 
-// Build constructs the final Parameter
+// Deprecated marks the parameter as deprecated with optional sunset date (YYYY-MM-DD) and replacement
+func (b *ParameterBuilder) Deprecated(since, sunset, replacedBy string) *ParameterBuilder {
+	b.parameter.Deprecated = &parser.Deprecation{Since: since, Sunset: sunset, ReplacedBy: replacedBy, LineNumber: b.parameter.LineNumber}
+	return b
+}
+
+// WithExtension sets a vendor extension of the parameter. Names without an "x-" prefix are given one.
+func (b *ParameterBuilder) WithExtension(name string, value interface{}) *ParameterBuilder {
+	b.parameter.Extensions = withExtension(b.parameter.Extensions, name, value)
+	return b
+}
+
+// Build constructs the final Parameter. Without an explicit schema, the schema follows from the type.
 func (b *ParameterBuilder) Build() *parser.Parameter {
+	if b.parameter.Schema == nil {
+		b.parameter.Schema = parser.SchemaFromTypeName(b.parameter.Type, b.parameter.LineNumber)
+	}
 	return b.parameter
 }
+
+// withExtension stores a vendor extension under its "x-" name, allocating the map on first use
+func withExtension(extensions map[string]interface{}, name string, value interface{}) map[string]interface{} {
+	if !strings.HasPrefix(name, "x-") {
+		name = "x-" + name
+	}
+	if extensions == nil {
+		extensions = make(map[string]interface{})
+	}
+	extensions[name] = value
+	return extensions
+}
@@ -46,7 +46,7 @@ func (b *SchemaBuilder) WithExample(example interface{}) *SchemaBuilder {
 // AddProperty adds a property to an object schema
 func (b *SchemaBuilder) AddProperty(name string, property *parser.Schema) *SchemaBuilder {
 	if name != "" && property != nil {
-		b.schema.Properties[name] = property
+		b.schema.SetProperty(name, property)
 	}
 	return b
 }
@@ -77,6 +77,143 @@ func (b *SchemaBuilder) WithRef(ref string) *SchemaBuilder {
 	return b
 }
 
+// WithDefault sets the default value
+func (b *SchemaBuilder) WithDefault(value interface{}) *SchemaBuilder {
+	b.schema.Default = value
+	return b
+}
+
+// WithConst restricts the schema to a single value
+func (b *SchemaBuilder) WithConst(value interface{}) *SchemaBuilder {
+	b.schema.Const = value
+	return b
+}
+
+// WithMinimum sets the inclusive minimum
+func (b *SchemaBuilder) WithMinimum(minimum float64) *SchemaBuilder {
+	b.schema.Minimum = &minimum
+	return b
+}
+
+// WithMaximum sets the inclusive maximum
+func (b *SchemaBuilder) WithMaximum(maximum float64) *SchemaBuilder {
+	b.schema.Maximum = &maximum
+	return b
+}
+
+// WithRange sets the inclusive minimum and maximum
+func (b *SchemaBuilder) WithRange(minimum, maximum float64) *SchemaBuilder {
+	return b.WithMinimum(minimum).WithMaximum(maximum)
+}
+
+// WithExclusiveMinimum sets the exclusive minimum
+func (b *SchemaBuilder) WithExclusiveMinimum(minimum float64) *SchemaBuilder {
+	b.schema.ExclusiveMinimum = &minimum
+	return b
+}
+
+// WithExclusiveMaximum sets the exclusive maximum
+func (b *SchemaBuilder) WithExclusiveMaximum(maximum float64) *SchemaBuilder {
+	b.schema.ExclusiveMaximum = &maximum
+	return b
+}
+
+// WithMultipleOf requires numbers to be a multiple of the given value
+func (b *SchemaBuilder) WithMultipleOf(value float64) *SchemaBuilder {
+	b.schema.MultipleOf = &value
+	return b
+}
+
+// WithMinLength sets the minimum string length
+func (b *SchemaBuilder) WithMinLength(length int) *SchemaBuilder {
+	b.schema.MinLength = &length
+	return b
+}
+
+// WithMaxLength sets the maximum string length
+func (b *SchemaBuilder) WithMaxLength(length int) *SchemaBuilder {
+	b.schema.MaxLength = &length
+	return b
+}
+
+// WithPattern sets the regular expression strings must match
+func (b *SchemaBuilder) WithPattern(pattern string) *SchemaBuilder {
+	b.schema.Pattern = pattern
+	return b
+}
+
+// WithMinItems sets the minimum array length
+func (b *SchemaBuilder) WithMinItems(count int) *SchemaBuilder {
+	b.schema.MinItems = &count
+	return b
+}
+
+// WithMaxItems sets the maximum array length
+func (b *SchemaBuilder) WithMaxItems(count int) *SchemaBuilder {
+	b.schema.MaxItems = &count
+	return b
+}
+
+// UniqueItems requires array items to be unique
+func (b *SchemaBuilder) UniqueItems() *SchemaBuilder {
+	b.schema.UniqueItems = true
+	return b
+}
+
+// WithMinProperties sets the minimum number of object properties
+func (b *SchemaBuilder) WithMinProperties(count int) *SchemaBuilder {
+	b.schema.MinProperties = &count
+	return b
+}
+
+// WithMaxProperties sets the maximum number of object properties
+func (b *SchemaBuilder) WithMaxProperties(count int) *SchemaBuilder {
+	b.schema.MaxProperties = &count
+	return b
+}
+
+// WithContentMediaType sets the media type of string-encoded content such as a file upload
+func (b *SchemaBuilder) WithContentMediaType(mediaType string) *SchemaBuilder {
+	b.schema.ContentMediaType = mediaType
+	return b
+}
+
+// WithContentEncoding sets the encoding of string content, e.g. "base64"
+func (b *SchemaBuilder) WithContentEncoding(encoding string) *SchemaBuilder {
+	b.schema.ContentEncoding = encoding
+	return b
+}
+
+// Deprecated marks the schema as deprecated with optional sunset date (YYYY-MM-DD) and replacement
+func (b *SchemaBuilder) Deprecated(since, sunset, replacedBy string) *SchemaBuilder {
+	b.schema.Deprecated = &parser.Deprecation{Since: since, Sunset: sunset, ReplacedBy: replacedBy, LineNumber: b.schema.LineNumber}
+	return b
+}
+
+// WithExtension sets a vendor extension of the schema. Names without an "x-" prefix are given one.
+func (b *SchemaBuilder) WithExtension(name string, value interface{}) *SchemaBuilder {
+	b.schema.Extensions = withExtension(b.schema.Extensions, name, value)
+	return b
+}
+
+// Nullable allows null in addition to the schema type
+func (b *SchemaBuilder) Nullable() *SchemaBuilder {
+	b.schema.Nullable = true
+	return b
+}
+
+// ReadOnly marks the schema as only returned in responses
+func (b *SchemaBuilder) ReadOnly() *SchemaBuilder {
+	b.schema.ReadOnly = true
+	return b
+}
+
+// WriteOnly marks the schema as only sent in requests
+func (b *SchemaBuilder) WriteOnly() *SchemaBuilder {
+	b.schema.WriteOnly = true
+	return b
+}
+
 // Build constructs the final Schema
 func (b *SchemaBuilder) Build() *parser.Schema {
 	return b.schema
@@ -167,6 +304,17 @@ func (b *RequestBodyBuilder) AddContent(mediaType string, schema *parser.Schema)
 	return b
 }
 
+// AddEncoding sets the content type of a multipart form field
+func (b *RequestBodyBuilder) AddEncoding(field, contentType string) *RequestBodyBuilder {
+	if field != "" && contentType != "" {
+		if b.requestBody.Encoding == nil {
+			b.requestBody.Encoding = make(map[string]*parser.Encoding)
+		}
+		b.requestBody.Encoding[field] = &parser.Encoding{ContentType: contentType}
+	}
+	return b
+}
+
 // Build constructs the final RequestBody
 func (b *RequestBodyBuilder) Build() *parser.RequestBody {
 	return b.requestBody
@@ -1,10 +1,20 @@
 package generator
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/sukhera/APIWeaver/internal/domain/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// Path orders of the generated paths and webhooks
+const (
+	PathOrderSource = "source" // the order of the markdown document
+	PathOrderSorted = "sorted" // sorted by path
 )
 
 // Config holds generator configuration
@@ -14,6 +24,9 @@ type Config struct {
 	IncludeExamples bool
 	ValidateOutput  bool
 	StrictMode      bool
+	PathOrder       string // PathOrderSource (default) or PathOrderSorted
+	HoistSchemas    bool   // move repeated inline object schemas into components
+	Target          string // Target31 (default), Target30 or Target20
 }
 
 // Generator generates OpenAPI specifications from parsed documents
@@ -30,143 +43,104 @@ func New(config Config) *Generator {
 
 // Generate generates an OpenAPI specification from a parsed document
 func (g *Generator) Generate(ctx context.Context, doc *parser.Document, format string) (string, error) {
+	output, _, err := g.GenerateWithReport(ctx, doc, format)
+	return output, err
+}
+
+// GenerateWithReport generates an OpenAPI specification and reports the changes made on top of
+// converting the document, such as hoisted schemas
+func (g *Generator) GenerateWithReport(ctx context.Context, doc *parser.Document, format string) (string, *Report, error) {
 	if doc == nil {
-		return "", fmt.Errorf("document is nil")
+		return "", nil, fmt.Errorf("document is nil")
 	}
 
-	// For MVP, return a mock OpenAPI spec based on the document
+	spec, err := buildSpec(ctx, doc, g.config.PathOrder)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build OpenAPI document: %w", err)
+	}
+
+	report := &Report{}
+	if g.config.HoistSchemas {
+		report.Hoisted = hoistSchemas(spec)
+	}
+
+	document, warnings, err := convertTarget(spec, g.config.Target)
+	if err != nil {
+		return "", nil, err
+	}
+	report.Warnings = warnings
+
+	var output string
 	switch format {
 	case "json":
-		return g.generateJSON(ctx, doc)
+		output, err = g.generateJSON(document)
 	case "yaml":
-		return g.generateYAML(ctx, doc)
+		output, err = g.generateYAML(document)
 	default:
-		return g.generateYAML(ctx, doc) // Default to YAML
+		output, err = g.generateYAML(document) // Default to YAML
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return output, report, nil
+}
+
+// generateYAML serializes the document as YAML. The document is converted through its JSON form, so both
+// formats always describe the same document; without pretty printing, YAML keeps the compact flow style.
+func (g *Generator) generateYAML(document interface{}) (string, error) {
+	data, err := encodeJSON(document)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return "", fmt.Errorf("failed to convert OpenAPI document to YAML: %w", err)
+	}
+	if g.config.PrettyPrint {
+		clearStyle(&node)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return "", fmt.Errorf("failed to encode OpenAPI document as YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode OpenAPI document as YAML: %w", err)
 	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
 }
 
-// generateYAML generates YAML format OpenAPI spec
-func (g *Generator) generateYAML(ctx context.Context, doc *parser.Document) (string, error) {
-	// Mock implementation - in real implementation this would use the AST
-	spec := `openapi: 3.1.0
-info:
-  title: Generated API
-  version: 1.0.0
-  description: API generated from markdown`
-
-	if doc.Frontmatter != nil {
-		if doc.Frontmatter.Title != "" {
-			spec = `openapi: 3.1.0
-info:
-  title: ` + doc.Frontmatter.Title + `
-  version: ` + getVersionOrDefault(doc.Frontmatter.Version) + `
-  description: ` + getDescriptionOrDefault(doc.Frontmatter.Description)
-		}
-	}
-
-	spec += `
-paths:`
-
-	// Add endpoints
-	if len(doc.Endpoints) == 0 {
-		spec += `
-  /example:
-    get:
-      summary: Example endpoint
-      responses:
-        '200':
-          description: Success
-          content:
-            application/json:
-              schema:
-                type: object
-                properties:
-                  message:
-                    type: string
-                    example: "Hello, World!"`
-	} else {
-		for _, endpoint := range doc.Endpoints {
-			spec += fmt.Sprintf(`
-  %s:
-    %s:
-      summary: %s
-      responses:
-        '200':
-          description: Success`,
-				endpoint.Path,
-				endpoint.Method,
-				getEndpointSummary(endpoint))
-		}
-	}
-
-	spec += `
-components:
-  schemas:
-    Error:
-      type: object
-      properties:
-        message:
-          type: string
-        code:
-          type: integer`
-
-	return spec, nil
+// generateJSON serializes the document as JSON, indented when pretty printing
+func (g *Generator) generateJSON(document interface{}) (string, error) {
+	data, err := encodeJSON(document)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	if !g.config.PrettyPrint {
+		return string(data), nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return "", fmt.Errorf("failed to indent OpenAPI document: %w", err)
+	}
+	return buf.String(), nil
 }
 
-// generateJSON generates JSON format OpenAPI spec
-func (g *Generator) generateJSON(ctx context.Context, doc *parser.Document) (string, error) {
-	// Mock implementation - in real implementation this would build proper JSON
-	return `{
-  "openapi": "3.1.0",
-  "info": {
-    "title": "Generated API",
-    "version": "1.0.0",
-    "description": "API generated from markdown"
-  },
-  "paths": {
-    "/example": {
-      "get": {
-        "summary": "Example endpoint",
-        "responses": {
-          "200": {
-            "description": "Success",
-            "content": {
-              "application/json": {
-                "schema": {
-                  "type": "object",
-                  "properties": {
-                    "message": {
-                      "type": "string",
-                      "example": "Hello, World!"
-                    }
-                  }
-                }
-              }
-            }
-          }
-        }
-      }
-    }
-  },
-  "components": {
-    "schemas": {
-      "Error": {
-        "type": "object",
-        "properties": {
-          "message": {
-            "type": "string"
-          },
-          "code": {
-            "type": "integer"
-          }
-        }
-      }
-    }
-  }
-}`, nil
+// clearStyle switches a YAML node tree from the flow style of its JSON source to block style,
+// letting the encoder quote only the strings that need it
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
 }
 
 // Helper functions
+
 func getVersionOrDefault(version string) string {
 	if version == "" {
 		return "1.0.0"
@@ -188,5 +162,5 @@ func getEndpointSummary(endpoint *parser.Endpoint) string {
 	if endpoint.Description != "" {
 		return endpoint.Description
 	}
-	return fmt.Sprintf("%s %s", endpoint.Method, endpoint.Path)
+	return strings.TrimSpace(fmt.Sprintf("%s %s", endpoint.Method, endpoint.Path))
 }
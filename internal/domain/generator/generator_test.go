@@ -0,0 +1,300 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/internal/domain/parser"
+	"github.com/sukhera/APIWeaver/testutil"
+	"gopkg.in/yaml.v3"
+)
+
+// generateTree parses markdown and generates it as JSON, decoded into a generic tree
+func generateTree(t *testing.T, content string, config Config) map[string]interface{} {
+	t.Helper()
+
+	doc, err := parser.New().Parse(content)
+	require.NoError(t, err)
+
+	output, err := New(config).Generate(context.Background(), doc, "json")
+	require.NoError(t, err)
+
+	var tree map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &tree))
+	return tree
+}
+
+// lookup follows a path of object keys through a decoded tree
+func lookup(t *testing.T, tree interface{}, keys ...string) interface{} {
+	t.Helper()
+
+	for _, key := range keys {
+		object, ok := tree.(map[string]interface{})
+		require.True(t, ok, "%q is not inside an object", key)
+		tree, ok = object[key]
+		require.True(t, ok, "missing key %q", key)
+	}
+	return tree
+}
+
+func TestGenerator_GenerateTestFixture(t *testing.T) {
+	doc, err := parser.New().Parse(testutil.CreateTestMarkdownContent())
+	require.NoError(t, err)
+
+	for _, pretty := range []bool{false, true} {
+		t.Run(fmt.Sprintf("pretty print %t", pretty), func(t *testing.T) {
+			g := New(Config{PrettyPrint: pretty})
+
+			jsonOutput, err := g.Generate(context.Background(), doc, "json")
+			require.NoError(t, err)
+			var fromJSON map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(jsonOutput), &fromJSON))
+
+			yamlOutput, err := g.Generate(context.Background(), doc, "yaml")
+			require.NoError(t, err)
+			var fromYAML map[string]interface{}
+			require.NoError(t, yaml.Unmarshal([]byte(yamlOutput), &fromYAML))
+
+			// YAML decodes integers as int, so compare both through their JSON form
+			data, err := json.Marshal(fromYAML)
+			require.NoError(t, err)
+			var normalized map[string]interface{}
+			require.NoError(t, json.Unmarshal(data, &normalized))
+			assert.Equal(t, fromJSON, normalized)
+
+			assert.Equal(t, openAPIVersion, lookup(t, fromJSON, "openapi"))
+			assert.Equal(t, map[string]interface{}{
+				"title":       "Test API",
+				"version":     "1.0.0",
+				"description": "Test API documentation",
+			}, lookup(t, fromJSON, "info"))
+			assert.NotContains(t, fromJSON, "tags")
+
+			operation := lookup(t, fromJSON, "paths", "/api/test", "get")
+			assert.Equal(t, "getApiTest", lookup(t, operation, "operationId"))
+			assert.Equal(t, "Test endpoint description", lookup(t, operation, "summary"))
+			assert.Equal(t, []interface{}{map[string]interface{}{
+				"name":        "test_param",
+				"in":          "query",
+				"description": "A test parameter",
+				"schema":      map[string]interface{}{"type": "string"},
+				"example":     "value",
+			}}, lookup(t, operation, "parameters"))
+			assert.Equal(t, "Success response", lookup(t, operation, "responses", "200", "description"))
+			assert.Equal(t, "string", lookup(t, operation,
+				"responses", "200", "content", "application/json", "schema", "properties", "message", "type"))
+		})
+	}
+}
+
+func TestGenerator_DocumentStructure(t *testing.T) {
+	content := "---\n" +
+		"title: Billing API\n" +
+		"x-audience: partner\n" +
+		"securitySchemes:\n" +
+		"  bearerAuth: bearer\n" +
+		"security: bearerAuth\n" +
+		"---\n\n" +
+		"## POST /invoices\n\n" +
+		"Create an invoice.\n\n" +
+		"**x-rate-limit:** 100\n\n" +
+		"### Query Parameters\n\n" +
+		"- **dry_run** (query, boolean, x-internal) - Validate only\n\n" +
+		"### Request Body\n\n" +
+		"```yaml\n" +
+		"type: object\n" +
+		"properties:\n" +
+		"  amount:\n" +
+		"    type: integer\n" +
+		"    x-unit: cents\n" +
+		"```\n\n" +
+		"## GET /health\n\n" +
+		"**Auth:** none\n\n" +
+		"## WEBHOOK invoice.paid\n\n" +
+		"Sent when an invoice is paid.\n"
+
+	tree := generateTree(t, content, Config{})
+
+	tests := []struct {
+		name     string
+		path     []string
+		expected interface{}
+	}{
+		{name: "document extension", path: []string{"x-audience"}, expected: "partner"},
+		{name: "operation extension", path: []string{"paths", "/invoices", "post", "x-rate-limit"}, expected: float64(100)},
+		{name: "schema extension", path: []string{
+			"paths", "/invoices", "post", "requestBody", "content", "application/json", "schema", "properties", "amount", "x-unit",
+		}, expected: "cents"},
+		{name: "security scheme in components", path: []string{"components", "securitySchemes", "bearerAuth"},
+			expected: map[string]interface{}{"type": "http", "scheme": "bearer"}},
+		{name: "document security", path: []string{"security"},
+			expected: []interface{}{map[string]interface{}{"bearerAuth": []interface{}{}}}},
+		{name: "public operation security", path: []string{"paths", "/health", "get", "security"}, expected: []interface{}{}},
+		{name: "webhook at the document root", path: []string{"webhooks", "invoice.paid", "post", "summary"},
+			expected: "Sent when an invoice is paid."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, lookup(t, tree, tt.path...))
+		})
+	}
+
+	parameter := lookup(t, tree, "paths", "/invoices", "post", "parameters").([]interface{})[0]
+	assert.Equal(t, true, lookup(t, parameter, "x-internal"))
+	assert.NotContains(t, lookup(t, tree, "paths").(map[string]interface{}), "invoice.paid")
+	assert.NotContains(t, lookup(t, tree, "paths", "/invoices", "post").(map[string]interface{}), "security")
+}
+
+func TestGenerator_WebhooksAndCallbacks(t *testing.T) {
+	content := "## POST /payments\n\n" +
+		"Start a payment.\n\n" +
+		"### Callbacks\n\n" +
+		"#### onPaid: POST {$request.body#/callbackUrl}\n\n" +
+		"Payment completed.\n\n" +
+		"**Request Body:**\n\n" +
+		"| Name | Type |\n" +
+		"| --- | --- |\n" +
+		"| paymentId | string |\n\n" +
+		"##### Responses\n\n" +
+		"- **204** - Acknowledged\n\n" +
+		"## WEBHOOK order.created\n\n" +
+		"Sent when an order is created.\n\n" +
+		"### Responses\n\n" +
+		"- **200** - Received\n\n" +
+		"## WEBHOOK DELETE order.created\n\n" +
+		"**Operation ID:** orderDeleted\n"
+
+	tree := generateTree(t, content, Config{})
+
+	callback := lookup(t, tree, "paths", "/payments", "post", "callbacks", "onPaid", "{$request.body#/callbackUrl}", "post")
+	assert.Equal(t, "Payment completed.", lookup(t, callback, "summary"))
+	assert.Equal(t, "string", lookup(t, callback,
+		"requestBody", "content", "application/json", "schema", "properties", "paymentId", "type"))
+	assert.Equal(t, map[string]interface{}{"204": map[string]interface{}{"description": "Acknowledged"}},
+		lookup(t, callback, "responses"))
+
+	webhook := lookup(t, tree, "webhooks", "order.created").(map[string]interface{})
+	assert.Len(t, webhook, 2)
+	assert.Equal(t, "postOrderCreated", lookup(t, webhook, "post", "operationId"))
+	assert.Equal(t, "Received", lookup(t, webhook, "post", "responses", "200", "description"))
+	assert.Equal(t, "orderDeleted", lookup(t, webhook, "delete", "operationId"))
+	assert.Equal(t, []string{"/payments"}, keys(lookup(t, tree, "paths")))
+}
+
+// keys returns the keys of a decoded object
+func keys(object interface{}) []string {
+	var names []string
+	for name := range object.(map[string]interface{}) {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestGenerator_ByteStableOutput(t *testing.T) {
+	frontmatter := "---\n" +
+		"title: Store API\n" +
+		"x-team: commerce\n" +
+		"x-audience: public\n" +
+		"---\n\n"
+	orders := "## GET /orders\n\n" +
+		"List orders.\n\n" +
+		"### Responses\n\n" +
+		"- **200** - Orders\n" +
+		"- **401** - Unauthorized\n" +
+		"- **500** - Server error\n\n"
+	reversedOrders := "## GET /orders\n\n" +
+		"List orders.\n\n" +
+		"### Responses\n\n" +
+		"- **500** - Server error\n" +
+		"- **401** - Unauthorized\n" +
+		"- **200** - Orders\n\n"
+	items := "## POST /items\n\n" +
+		"Create an item.\n\n" +
+		"### Request Body\n\n" +
+		"```json\n" +
+		"{\"name\": \"Lamp\", \"price\": 20, \"tags\": [\"home\"], \"stock\": {\"count\": 3, \"warehouse\": \"north\"}}\n" +
+		"```\n\n" +
+		"## DELETE /items/{id}\n\n" +
+		"**Auth:** none\n\n" +
+		"## GET /items/{id}\n\n" +
+		"Get an item.\n\n"
+	accounts := "## GET /accounts\n\n" +
+		"List accounts.\n\n"
+
+	source := frontmatter + orders + items + accounts
+	shuffled := frontmatter + accounts + items + reversedOrders
+
+	tests := []struct {
+		name      string
+		pathOrder string
+		paths     []string // path order generated from the source document
+		shuffled  []string // path order generated from the shuffled document
+	}{
+		{
+			name:      "source order",
+			pathOrder: PathOrderSource,
+			paths:     []string{"/orders", "/items", "/items/{id}", "/accounts"},
+			shuffled:  []string{"/accounts", "/items", "/items/{id}", "/orders"},
+		},
+		{
+			name:      "sorted order",
+			pathOrder: PathOrderSorted,
+			paths:     []string{"/accounts", "/items", "/items/{id}", "/orders"},
+			shuffled:  []string{"/accounts", "/items", "/items/{id}", "/orders"},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, format := range []string{"json", "yaml"} {
+			t.Run(tt.name+" "+format, func(t *testing.T) {
+				g := New(Config{PathOrder: tt.pathOrder, PrettyPrint: true})
+				generate := func(content string) string {
+					doc, err := parser.New().Parse(content)
+					require.NoError(t, err)
+					output, err := g.Generate(context.Background(), doc, format)
+					require.NoError(t, err)
+					return output
+				}
+
+				expected := generate(source)
+				for i := 0; i < 10; i++ {
+					require.Equal(t, expected, generate(source), "run %d differs", i)
+				}
+				assert.Equal(t, tt.paths, pathKeys(t, expected))
+
+				reordered := generate(shuffled)
+				assert.Equal(t, tt.shuffled, pathKeys(t, reordered))
+				if tt.pathOrder == PathOrderSorted {
+					assert.Equal(t, expected, reordered)
+				}
+			})
+		}
+	}
+}
+
+// pathKeys returns the keys of the paths object in output order. JSON is valid YAML, so both formats decode.
+func pathKeys(t *testing.T, output string) []string {
+	t.Helper()
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(output), &root))
+	document := root.Content[0]
+	for i := 0; i < len(document.Content); i += 2 {
+		if document.Content[i].Value != "paths" {
+			continue
+		}
+		var keys []string
+		paths := document.Content[i+1]
+		for j := 0; j < len(paths.Content); j += 2 {
+			keys = append(keys, paths.Content[j].Value)
+		}
+		return keys
+	}
+	require.Fail(t, "missing paths")
+	return nil
+}
@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// componentSchemaPrefix is the reference prefix of component schemas
+const componentSchemaPrefix = "#/components/schemas/"
+
+// pointerEscaper escapes a JSON pointer reference token
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Report describes what the generator changed beyond a direct conversion of the document
+type Report struct {
+	Hoisted  []HoistedSchema `json:"hoisted,omitempty"`
+	Warnings []string        `json:"warnings,omitempty"` // what the target cannot represent
+}
+
+// HoistedSchema is an inline schema replaced by a reference to a component
+type HoistedSchema struct {
+	Name      string   `json:"name"`
+	Reused    bool     `json:"reused,omitempty"` // the schema matched a component declared in the document
+	Locations []string `json:"locations"`        // JSON pointers of the replaced inline schemas
+}
+
+// schemaGroup collects the slots of structurally identical schemas
+type schemaGroup struct {
+	slots []schemaSlot
+}
+
+// hoister moves structurally identical inline object schemas into components and replaces them with references
+type hoister struct {
+	spec       *Spec
+	components map[string]string // key: schema hash, value: component name
+	report     map[string]*HoistedSchema
+	order      []string
+}
+
+// hoistSchemas replaces inline object schemas that occur more than once, or that match a declared
+// component, with references to components. Outer schemas are hoisted before the schemas nested in them,
+// so a schema only repeated inside one hoisted schema stays inline.
+func hoistSchemas(spec *Spec) []HoistedSchema {
+	h := &hoister{
+		spec:       spec,
+		components: make(map[string]string),
+		report:     make(map[string]*HoistedSchema),
+	}
+	if spec.Components != nil {
+		for _, name := range sortedKeys(spec.Components.Schemas) {
+			if hash := schemaHash(spec.Components.Schemas[name]); h.components[hash] == "" {
+				h.components[hash] = name
+			}
+		}
+	}
+
+	for {
+		groups := make(map[string]*schemaGroup)
+		var hashes []string
+		walkSchemas(spec, func(slot schemaSlot) bool {
+			if !isHoistable(slot) {
+				return true
+			}
+			hash := schemaHash(*slot.schema)
+			group := groups[hash]
+			if group == nil {
+				group = &schemaGroup{}
+				groups[hash] = group
+				hashes = append(hashes, hash)
+			}
+			group.slots = append(group.slots, slot)
+			return true
+		})
+
+		chosen := make(map[string]bool)
+		for _, hash := range hashes {
+			if hash != "" && (len(groups[hash].slots) > 1 || h.components[hash] != "") {
+				chosen[hash] = true
+			}
+		}
+		if len(chosen) == 0 {
+			break
+		}
+
+		walkSchemas(spec, func(slot schemaSlot) bool {
+			if !isHoistable(slot) {
+				return true
+			}
+			hash := schemaHash(*slot.schema)
+			if hash == "" || !chosen[hash] {
+				return true
+			}
+			h.replace(slot, hash, groups[hash])
+			return false
+		})
+	}
+
+	hoisted := make([]HoistedSchema, 0, len(h.order))
+	for _, name := range h.order {
+		hoisted = append(hoisted, *h.report[name])
+	}
+	return hoisted
+}
+
+// replace points a slot at the component of its schema, creating the component from the first slot
+func (h *hoister) replace(slot schemaSlot, hash string, group *schemaGroup) {
+	name := h.components[hash]
+	entry := h.report[name]
+	if name == "" {
+		name = h.uniqueName(groupName(group))
+		components := h.spec.Components
+		if components == nil {
+			components = &Components{}
+			h.spec.Components = components
+		}
+		if components.Schemas == nil {
+			components.Schemas = make(map[string]*Schema)
+		}
+		components.Schemas[name] = *slot.schema
+		h.components[hash] = name
+		entry = &HoistedSchema{Name: name}
+		h.report[name] = entry
+		h.order = append(h.order, name)
+	} else if entry == nil {
+		entry = &HoistedSchema{Name: name, Reused: true}
+		h.report[name] = entry
+		h.order = append(h.order, name)
+	}
+
+	entry.Locations = append(entry.Locations, slot.pointer)
+	*slot.schema = &Schema{Ref: componentSchemaPrefix + name}
+}
+
+// uniqueName returns the name, numbered when a component already uses it
+func (h *hoister) uniqueName(name string) string {
+	taken := func(candidate string) bool {
+		if h.spec.Components == nil {
+			return false
+		}
+		_, ok := h.spec.Components.Schemas[candidate]
+		return ok
+	}
+	if !taken(name) {
+		return name
+	}
+	for i := 2; ; i++ {
+		if candidate := name + strconv.Itoa(i); !taken(candidate) {
+			return candidate
+		}
+	}
+}
+
+// isHoistable reports whether a slot holds an inline object schema. Component schemas are not
+// candidates themselves, but the schemas nested in them are.
+func isHoistable(slot schemaSlot) bool {
+	s := *slot.schema
+	return !slot.root && s.Ref == "" && s.Properties != nil && s.Properties.Len() > 0
+}
+
+// groupName picks the component name of a group, preferring names taken from properties and parameters
+func groupName(group *schemaGroup) string {
+	for _, slot := range group.slots {
+		if slot.semantic && slot.name != "" {
+			return slot.name
+		}
+	}
+	for _, slot := range group.slots {
+		if slot.name != "" {
+			return slot.name
+		}
+	}
+	return "Schema"
+}
+
+// schemaHash identifies the structure of a schema. Keys are hashed sorted, so the declaration order of
+// properties does not tell two schemas apart.
+func schemaHash(s *Schema) string {
+	data, err := encodeJSON(s)
+	if err != nil {
+		return ""
+	}
+	var canonical interface{}
+	if err := json.Unmarshal(data, &canonical); err != nil {
+		return ""
+	}
+	if data, err = encodeJSON(canonical); err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// componentName turns an identifier such as "getUsers" or "x-rate-limit" into "GetUsers" or "XRateLimit"
+func componentName(identifier string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(identifier, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// singular names the items of a list, e.g. "User" for "Users"
+func singular(name string) string {
+	switch {
+	case name == "":
+		return ""
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && len(name) > 1:
+		return strings.TrimSuffix(name, "s")
+	default:
+		return name + "Item"
+	}
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/internal/domain/parser"
+)
+
+// userBody is a request body with an inline user object
+const userBody = "### Request Body\n\n" +
+	"```yaml\n" +
+	"type: object\n" +
+	"properties:\n" +
+	"  user:\n" +
+	"    type: object\n" +
+	"    properties:\n" +
+	"      id:\n" +
+	"        type: integer\n" +
+	"      name:\n" +
+	"        type: string\n" +
+	"```\n\n"
+
+// userResponse is a response with the inline user object of userBody, its properties in another order,
+// inside an object that differs from the request body
+const userResponse = "### Responses\n\n" +
+	"#### 200 - OK\n\n" +
+	"```yaml\n" +
+	"type: object\n" +
+	"properties:\n" +
+	"  etag:\n" +
+	"    type: string\n" +
+	"  user:\n" +
+	"    type: object\n" +
+	"    properties:\n" +
+	"      name:\n" +
+	"        type: string\n" +
+	"      id:\n" +
+	"        type: integer\n" +
+	"```\n\n"
+
+// userBodyPointer and userResponsePointer locate the inline user objects
+const (
+	userBodyPointer     = "#/paths/~1users/post/requestBody/content/application~1json/schema/properties/user"
+	userResponsePointer = "#/paths/~1users~1{id}/get/responses/200/content/application~1json/schema/properties/user"
+)
+
+func TestHoistSchemas(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []HoistedSchema
+		refs     map[string][]string // key: expected reference, value: path of the schema holding it
+	}{
+		{
+			name: "hoists a repeated object regardless of property order",
+			content: "## POST /users\n\n" + userBody +
+				"## GET /users/{id}\n\n" + userResponse,
+			expected: []HoistedSchema{{Name: "User", Locations: []string{userBodyPointer, userResponsePointer}}},
+			refs: map[string][]string{
+				"#/components/schemas/User": {
+					"paths", "/users", "post", "requestBody", "content", "application/json", "schema", "properties", "user",
+				},
+			},
+		},
+		{
+			name:     "keeps a single occurrence inline",
+			content:  "## POST /users\n\n" + userBody,
+			expected: []HoistedSchema{},
+		},
+		{
+			name: "reuses a matching declared component",
+			content: "# Components\n\n" +
+				"## Schema: Account\n\n" +
+				"```yaml\n" +
+				"type: object\n" +
+				"properties:\n" +
+				"  name:\n" +
+				"    type: string\n" +
+				"  id:\n" +
+				"    type: integer\n" +
+				"```\n\n" +
+				"## POST /users\n\n" + userBody,
+			expected: []HoistedSchema{{Name: "Account", Reused: true, Locations: []string{userBodyPointer}}},
+			refs: map[string][]string{
+				"#/components/schemas/Account": {
+					"paths", "/users", "post", "requestBody", "content", "application/json", "schema", "properties", "user",
+				},
+			},
+		},
+		{
+			name: "numbers a name taken by a declared component",
+			content: "# Components\n\n" +
+				"## Schema: User\n\n" +
+				"```yaml\n" +
+				"type: string\n" +
+				"```\n\n" +
+				"## POST /users\n\n" + userBody +
+				"## GET /users/{id}\n\n" + userResponse,
+			expected: []HoistedSchema{{Name: "User2", Locations: []string{userBodyPointer, userResponsePointer}}},
+			refs: map[string][]string{
+				"#/components/schemas/User2": {
+					"paths", "/users/{id}", "get", "responses", "200", "content", "application/json", "schema", "properties", "user",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.New().Parse(tt.content)
+			require.NoError(t, err)
+			spec, err := buildSpec(context.Background(), doc, PathOrderSource)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, hoistSchemas(spec))
+
+			data, err := encodeJSON(spec)
+			require.NoError(t, err)
+			var tree map[string]interface{}
+			require.NoError(t, json.Unmarshal(data, &tree))
+			for ref, path := range tt.refs {
+				assert.Equal(t, map[string]interface{}{"$ref": ref}, lookup(t, tree, path...))
+			}
+			for _, hoisted := range tt.expected {
+				component := lookup(t, tree, "components", "schemas", hoisted.Name)
+				assert.Equal(t, "object", lookup(t, component, "type"))
+			}
+		})
+	}
+}
+
+func TestSchemaHash(t *testing.T) {
+	object := func(names ...string) *Schema {
+		properties := NewOrderedMap[*Schema]()
+		for _, name := range names {
+			properties.Set(name, &Schema{Type: "string"})
+		}
+		return &Schema{Type: "object", Properties: properties}
+	}
+
+	tests := []struct {
+		name  string
+		a     *Schema
+		b     *Schema
+		equal bool
+	}{
+		{name: "same properties", a: object("id", "name"), b: object("id", "name"), equal: true},
+		{name: "properties in another order", a: object("id", "name"), b: object("name", "id"), equal: true},
+		{name: "different properties", a: object("id", "name"), b: object("id", "email"), equal: false},
+		{name: "missing property", a: object("id", "name"), b: object("id"), equal: false},
+		{name: "different type", a: &Schema{Type: "string"}, b: &Schema{Type: "integer"}, equal: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotEmpty(t, schemaHash(tt.a))
+			assert.Equal(t, tt.equal, schemaHash(tt.a) == schemaHash(tt.b))
+		})
+	}
+}
+
+func TestComponentNames(t *testing.T) {
+	tests := []struct {
+		identifier string
+		component  string
+		singular   string
+	}{
+		{identifier: "getUsers", component: "GetUsers", singular: "GetUser"},
+		{identifier: "x-rate-limit", component: "XRateLimit", singular: "XRateLimitItem"},
+		{identifier: "categories", component: "Categories", singular: "Category"},
+		{identifier: "address", component: "Address", singular: "AddressItem"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identifier, func(t *testing.T) {
+			assert.Equal(t, tt.component, componentName(tt.identifier))
+			assert.Equal(t, tt.singular, singular(componentName(tt.identifier)))
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/internal/domain/parser"
+)
+
+// jsonSchemaDialect identifies JSON Schema 2020-12, the dialect of exported schemas
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaExtension is the file name extension of exported schemas
+const jsonSchemaExtension = ".schema.json"
+
+// pointerUnescaper decodes a JSON pointer reference token
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// JSONSchemaConfig configures a JSON Schema export
+type JSONSchemaConfig struct {
+	BaseID string // URI the file names are resolved against to form each "$id"; ids are relative when empty
+	Bundle string // file name of a bundle holding every component under "$defs"; no bundle when empty
+}
+
+// JSONSchemaFile is an exported JSON Schema document
+type JSONSchemaFile struct {
+	Name      string `json:"name"` // file name
+	ID        string `json:"id"`
+	Component string `json:"component,omitempty"` // empty for the bundle
+	Content   string `json:"content"`
+}
+
+// jsonSchemaDocument is a root schema with the keywords that identify it
+type jsonSchemaDocument struct {
+	Dialect string               `json:"$schema"`
+	ID      string               `json:"$id"`
+	Title   string               `json:"title,omitempty"`
+	Defs    *OrderedMap[*Schema] `json:"$defs,omitempty"`
+	root    *Schema
+}
+
+// MarshalJSON serializes the identifying keywords followed by the keywords of the root schema
+func (d *jsonSchemaDocument) MarshalJSON() ([]byte, error) {
+	type plain jsonSchemaDocument
+	data, err := encodeJSON((*plain)(d))
+	if err != nil || d.root == nil {
+		return data, err
+	}
+	root, err := encodeJSON(d.root)
+	if err != nil {
+		return nil, err
+	}
+	return joinObjects(data, root), nil
+}
+
+// componentCollector gathers the component schemas of a document in source order
+type componentCollector struct {
+	parser.BaseVisitor
+	schemas *OrderedMap[*parser.Schema]
+}
+
+// VisitComponent records a component schema. A later component of the same name replaces an earlier one.
+func (c *componentCollector) VisitComponent(ctx context.Context, component *parser.Component) error {
+	if component.Schema != nil {
+		c.schemas.Set(component.Name, component.Schema)
+	}
+	return nil
+}
+
+// GenerateJSONSchemas exports every component of a document as a standalone JSON Schema 2020-12 document
+// addressed by "$id". References between components become relative references to the other files;
+// the optional bundle holds every component under "$defs" instead.
+func (g *Generator) GenerateJSONSchemas(ctx context.Context, doc *parser.Document, config JSONSchemaConfig) ([]JSONSchemaFile, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("document is nil")
+	}
+
+	collector := &componentCollector{schemas: NewOrderedMap[*parser.Schema]()}
+	if err := doc.Accept(ctx, collector); err != nil {
+		return nil, fmt.Errorf("failed to collect components: %w", err)
+	}
+	names := collector.schemas.Keys()
+
+	fileNames := make(map[string]string, len(names)) // key: component name, value: file name
+	taken := make(map[string]bool)
+	if config.Bundle != "" {
+		taken[strings.ToLower(config.Bundle)] = true
+	}
+	for _, name := range names {
+		fileNames[name] = uniqueFileName(name, taken)
+	}
+
+	files := make([]JSONSchemaFile, 0, len(names)+1)
+	for _, name := range names {
+		source, _ := collector.schemas.Get(name)
+		root := schema(source)
+		rewriteComponentRefs(&root, func(target, fragment string) (string, bool) {
+			if target == name {
+				return "#" + fragment, true
+			}
+			fileName, ok := fileNames[target]
+			if !ok {
+				return "", false
+			}
+			if fragment != "" {
+				fileName += "#" + fragment
+			}
+			return fileName, true
+		})
+
+		file, err := g.jsonSchemaFile(&jsonSchemaDocument{
+			Dialect: jsonSchemaDialect,
+			ID:      schemaID(config.BaseID, fileNames[name]),
+			Title:   name,
+			root:    root,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to export component %s: %w", name, err)
+		}
+		file.Name = fileNames[name]
+		file.Component = name
+		files = append(files, file)
+	}
+
+	if config.Bundle != "" {
+		defs := NewOrderedMap[*Schema]()
+		for _, name := range names {
+			source, _ := collector.schemas.Get(name)
+			root := schema(source)
+			rewriteComponentRefs(&root, func(target, fragment string) (string, bool) {
+				if _, ok := fileNames[target]; !ok {
+					return "", false
+				}
+				return "#/$defs/" + pointerEscaper.Replace(target) + fragment, true
+			})
+			defs.Set(name, root)
+		}
+
+		file, err := g.jsonSchemaFile(&jsonSchemaDocument{
+			Dialect: jsonSchemaDialect,
+			ID:      schemaID(config.BaseID, config.Bundle),
+			Defs:    defs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to export schema bundle: %w", err)
+		}
+		file.Name = config.Bundle
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// jsonSchemaFile serializes an exported document as JSON, indented when pretty printing
+func (g *Generator) jsonSchemaFile(document *jsonSchemaDocument) (JSONSchemaFile, error) {
+	content, err := g.generateJSON(document)
+	if err != nil {
+		return JSONSchemaFile{}, err
+	}
+	return JSONSchemaFile{ID: document.ID, Content: content}, nil
+}
+
+// rewriteComponentRefs replaces the "#/components/schemas/" references of a schema tree. target receives the
+// component name and the JSON pointer fragment within it, and reports false to keep a reference as it is.
+func rewriteComponentRefs(root **Schema, target func(name, fragment string) (string, bool)) {
+	walkSchema(schemaSlot{schema: root}, func(slot schemaSlot) bool {
+		s := *slot.schema
+		if !strings.HasPrefix(s.Ref, componentSchemaPrefix) {
+			return true
+		}
+		token, rest, found := strings.Cut(strings.TrimPrefix(s.Ref, componentSchemaPrefix), "/")
+		fragment := ""
+		if found {
+			fragment = "/" + rest
+		}
+		if ref, ok := target(pointerUnescaper.Replace(token), fragment); ok {
+			s.Ref = ref
+		}
+		return true
+	})
+}
+
+// uniqueFileName names the file of a component, replacing characters that are unsafe in file names and
+// numbering names that differ only in case from one already taken
+func uniqueFileName(name string, taken map[string]bool) string {
+	base := strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+
+	candidate := base + jsonSchemaExtension
+	for i := 2; taken[strings.ToLower(candidate)]; i++ {
+		candidate = fmt.Sprintf("%s%d%s", base, i, jsonSchemaExtension)
+	}
+	taken[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// schemaID resolves a file name against the base URI, leaving it relative without one
+func schemaID(baseID, fileName string) string {
+	if baseID == "" {
+		return fileName
+	}
+	return strings.TrimSuffix(baseID, "/") + "/" + fileName
+}
@@ -0,0 +1,179 @@
+package generator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/internal/domain/parser"
+)
+
+// componentsContent declares components referencing each other, themselves and a property of another
+// component, and two names that differ only in case
+const componentsContent = "# Components\n\n" +
+	"## Schema: User\n\n" +
+	"```yaml\n" +
+	"type: object\n" +
+	"properties:\n" +
+	"  id:\n" +
+	"    type: string\n" +
+	"  address:\n" +
+	"    $ref: Address\n" +
+	"  manager:\n" +
+	"    $ref: User\n" +
+	"```\n\n" +
+	"## Schema: Address\n\n" +
+	"```yaml\n" +
+	"type: object\n" +
+	"properties:\n" +
+	"  ownerId:\n" +
+	"    $ref: '#/components/schemas/User/properties/id'\n" +
+	"```\n\n" +
+	"## Schema: user\n\n" +
+	"```yaml\n" +
+	"type: string\n" +
+	"```\n"
+
+func TestGenerator_GenerateJSONSchemas(t *testing.T) {
+	doc, err := parser.New().Parse(componentsContent)
+	require.NoError(t, err)
+	require.Empty(t, doc.Errors)
+
+	tests := []struct {
+		name     string
+		config   JSONSchemaConfig
+		expected []JSONSchemaFile
+	}{
+		{
+			name: "relative ids",
+			expected: []JSONSchemaFile{
+				{Name: "User.schema.json", ID: "User.schema.json", Component: "User", Content: `{
+					"$schema": "https://json-schema.org/draft/2020-12/schema",
+					"$id": "User.schema.json",
+					"title": "User",
+					"type": "object",
+					"properties": {
+						"id": {"type": "string"},
+						"address": {"$ref": "Address.schema.json"},
+						"manager": {"$ref": "#"}
+					}
+				}`},
+				{Name: "Address.schema.json", ID: "Address.schema.json", Component: "Address", Content: `{
+					"$schema": "https://json-schema.org/draft/2020-12/schema",
+					"$id": "Address.schema.json",
+					"title": "Address",
+					"type": "object",
+					"properties": {"ownerId": {"$ref": "User.schema.json#/properties/id"}}
+				}`},
+				{Name: "user2.schema.json", ID: "user2.schema.json", Component: "user", Content: `{
+					"$schema": "https://json-schema.org/draft/2020-12/schema",
+					"$id": "user2.schema.json",
+					"title": "user",
+					"type": "string"
+				}`},
+			},
+		},
+		{
+			name:   "base id and bundle",
+			config: JSONSchemaConfig{BaseID: "https://example.com/schemas/", Bundle: "bundle.schema.json"},
+			expected: []JSONSchemaFile{
+				{Name: "User.schema.json", ID: "https://example.com/schemas/User.schema.json", Component: "User", Content: `{
+					"$schema": "https://json-schema.org/draft/2020-12/schema",
+					"$id": "https://example.com/schemas/User.schema.json",
+					"title": "User",
+					"type": "object",
+					"properties": {
+						"id": {"type": "string"},
+						"address": {"$ref": "Address.schema.json"},
+						"manager": {"$ref": "#"}
+					}
+				}`},
+				{Name: "Address.schema.json", ID: "https://example.com/schemas/Address.schema.json", Component: "Address", Content: `{
+					"$schema": "https://json-schema.org/draft/2020-12/schema",
+					"$id": "https://example.com/schemas/Address.schema.json",
+					"title": "Address",
+					"type": "object",
+					"properties": {"ownerId": {"$ref": "User.schema.json#/properties/id"}}
+				}`},
+				{Name: "user2.schema.json", ID: "https://example.com/schemas/user2.schema.json", Component: "user", Content: `{
+					"$schema": "https://json-schema.org/draft/2020-12/schema",
+					"$id": "https://example.com/schemas/user2.schema.json",
+					"title": "user",
+					"type": "string"
+				}`},
+				{Name: "bundle.schema.json", ID: "https://example.com/schemas/bundle.schema.json", Content: `{
+					"$schema": "https://json-schema.org/draft/2020-12/schema",
+					"$id": "https://example.com/schemas/bundle.schema.json",
+					"$defs": {
+						"User": {
+							"type": "object",
+							"properties": {
+								"id": {"type": "string"},
+								"address": {"$ref": "#/$defs/Address"},
+								"manager": {"$ref": "#/$defs/User"}
+							}
+						},
+						"Address": {
+							"type": "object",
+							"properties": {"ownerId": {"$ref": "#/$defs/User/properties/id"}}
+						},
+						"user": {"type": "string"}
+					}
+				}`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := New(Config{}).GenerateJSONSchemas(context.Background(), doc, tt.config)
+			require.NoError(t, err)
+			require.Len(t, files, len(tt.expected))
+
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected.Name, files[i].Name)
+				assert.Equal(t, expected.ID, files[i].ID)
+				assert.Equal(t, expected.Component, files[i].Component)
+				assert.JSONEq(t, expected.Content, files[i].Content)
+			}
+		})
+	}
+}
+
+func TestUniqueFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		names    []string
+		taken    []string
+		expected []string
+	}{
+		{name: "distinct names", names: []string{"User", "Address"},
+			expected: []string{"User.schema.json", "Address.schema.json"}},
+		{name: "names differing in case", names: []string{"User", "user", "USER"},
+			expected: []string{"User.schema.json", "user2.schema.json", "USER3.schema.json"}},
+		{name: "unsafe characters", names: []string{"Order Item", "a/b"},
+			expected: []string{"Order_Item.schema.json", "a_b.schema.json"}},
+		{name: "name taken by the bundle", names: []string{"Bundle"}, taken: []string{"bundle.schema.json"},
+			expected: []string{"Bundle2.schema.json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taken := make(map[string]bool)
+			for _, name := range tt.taken {
+				taken[name] = true
+			}
+			var fileNames []string
+			for _, name := range tt.names {
+				fileNames = append(fileNames, uniqueFileName(name, taken))
+			}
+			assert.Equal(t, tt.expected, fileNames)
+		})
+	}
+}
+
+func TestGenerator_GenerateJSONSchemasNilDocument(t *testing.T) {
+	_, err := New(Config{}).GenerateJSONSchemas(context.Background(), nil, JSONSchemaConfig{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,271 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// openAPIVersion is the OpenAPI version of generated specifications
+const openAPIVersion = "3.1.0"
+
+// Spec is an OpenAPI 3.1 document. Fields follow the order of the specification, and the
+// vendor extensions of each object are serialized as keys of that object. Maps serialize with
+// sorted keys and ordered maps in their insertion order, so the output is byte-stable.
+type Spec struct {
+	OpenAPI    string                 `json:"openapi"`
+	Info       Info                   `json:"info"`
+	Servers    []Server               `json:"servers,omitempty"`
+	Tags       []Tag                  `json:"tags,omitempty"`
+	Security   []SecurityRequirement  `json:"security,omitempty"`
+	Paths      *OrderedMap[*PathItem] `json:"paths"` // key: path template
+	Webhooks   *OrderedMap[*PathItem] `json:"webhooks,omitempty"`
+	Components *Components            `json:"components,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is a base URL of the API
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag groups operations
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// SecurityRequirement maps security scheme names to the scopes they require
+type SecurityRequirement map[string][]string
+
+// PathItem holds the operations of one path, webhook or callback expression, in the canonical method order
+type PathItem struct {
+	Get     *Operation `json:"get,omitempty"`
+	Put     *Operation `json:"put,omitempty"`
+	Post    *Operation `json:"post,omitempty"`
+	Delete  *Operation `json:"delete,omitempty"`
+	Options *Operation `json:"options,omitempty"`
+	Head    *Operation `json:"head,omitempty"`
+	Patch   *Operation `json:"patch,omitempty"`
+	Trace   *Operation `json:"trace,omitempty"`
+}
+
+// Operation is a single API operation on a path item
+type Operation struct {
+	Tags        []string               `json:"tags,omitempty"`
+	Summary     string                 `json:"summary,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	OperationID string                 `json:"operationId,omitempty"`
+	Parameters  []*Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]*Response   `json:"responses"` // key: status code
+	Callbacks   map[string]Callback    `json:"callbacks,omitempty"`
+	Deprecated  bool                   `json:"deprecated,omitempty"`
+	Security    *[]SecurityRequirement `json:"security,omitempty"` // nil inherits the document requirements, empty is public
+	Extensions  map[string]interface{} `json:"-"`
+}
+
+// Callback maps runtime expressions to the requests sent to them
+type Callback map[string]*PathItem
+
+// Parameter is an operation parameter
+type Parameter struct {
+	Name        string                 `json:"name"`
+	In          string                 `json:"in"`
+	Description string                 `json:"description,omitempty"`
+	Required    bool                   `json:"required,omitempty"`
+	Deprecated  bool                   `json:"deprecated,omitempty"`
+	Schema      *Schema                `json:"schema,omitempty"`
+	Example     interface{}            `json:"example,omitempty"`
+	Extensions  map[string]interface{} `json:"-"`
+}
+
+// RequestBody is the body of an operation request
+type RequestBody struct {
+	Description string                 `json:"description,omitempty"`
+	Content     map[string]*MediaType  `json:"content"` // key: media type
+	Required    bool                   `json:"required,omitempty"`
+	Extensions  map[string]interface{} `json:"-"`
+}
+
+// MediaType is the schema of a body in one media type
+type MediaType struct {
+	Schema   *Schema              `json:"schema,omitempty"`
+	Encoding map[string]*Encoding `json:"encoding,omitempty"` // key: form field name
+}
+
+// Encoding describes how a multipart form field is serialized
+type Encoding struct {
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// Response is the response of an operation for one status code
+type Response struct {
+	Description string                 `json:"description"`
+	Headers     map[string]*Header     `json:"headers,omitempty"`
+	Content     map[string]*MediaType  `json:"content,omitempty"` // key: media type
+	Extensions  map[string]interface{} `json:"-"`
+}
+
+// Header is a response header
+type Header struct {
+	Description string      `json:"description,omitempty"`
+	Schema      *Schema     `json:"schema,omitempty"`
+	Example     interface{} `json:"example,omitempty"`
+}
+
+// Schema is a JSON Schema 2020-12 schema
+type Schema struct {
+	Ref              string                 `json:"$ref,omitempty"`
+	Type             interface{}            `json:"type,omitempty"` // a type name, or a list when null is allowed
+	Format           string                 `json:"format,omitempty"`
+	Description      string                 `json:"description,omitempty"`
+	Pattern          string                 `json:"pattern,omitempty"`
+	ContentMediaType string                 `json:"contentMediaType,omitempty"`
+	ContentEncoding  string                 `json:"contentEncoding,omitempty"`
+	Minimum          *float64               `json:"minimum,omitempty"`
+	Maximum          *float64               `json:"maximum,omitempty"`
+	ExclusiveMinimum interface{}            `json:"exclusiveMinimum,omitempty"` // a number, or a flag beside minimum before 3.1
+	ExclusiveMaximum interface{}            `json:"exclusiveMaximum,omitempty"` // a number, or a flag beside maximum before 3.1
+	MultipleOf       *float64               `json:"multipleOf,omitempty"`
+	MinLength        *int                   `json:"minLength,omitempty"`
+	MaxLength        *int                   `json:"maxLength,omitempty"`
+	MinItems         *int                   `json:"minItems,omitempty"`
+	MaxItems         *int                   `json:"maxItems,omitempty"`
+	MinProperties    *int                   `json:"minProperties,omitempty"`
+	MaxProperties    *int                   `json:"maxProperties,omitempty"`
+	UniqueItems      bool                   `json:"uniqueItems,omitempty"`
+	ReadOnly         bool                   `json:"readOnly,omitempty"`
+	WriteOnly        bool                   `json:"writeOnly,omitempty"`
+	Nullable         bool                   `json:"nullable,omitempty"` // OpenAPI 3.0 only; 3.1 lists "null" in Type
+	Deprecated       bool                   `json:"deprecated,omitempty"`
+	Enum             []interface{}          `json:"enum,omitempty"`
+	Const            interface{}            `json:"const,omitempty"`
+	Default          interface{}            `json:"default,omitempty"`
+	Example          interface{}            `json:"example,omitempty"`  // before 3.1
+	Examples         []interface{}          `json:"examples,omitempty"` // 3.1
+	Required         []string               `json:"required,omitempty"`
+	Properties       *OrderedMap[*Schema]   `json:"properties,omitempty"` // in declaration order
+	Items            *Schema                `json:"items,omitempty"`
+	AllOf            []*Schema              `json:"allOf,omitempty"`
+	OneOf            []*Schema              `json:"oneOf,omitempty"`
+	AnyOf            []*Schema              `json:"anyOf,omitempty"`
+	Extensions       map[string]interface{} `json:"-"`
+}
+
+// Components holds the reusable objects of the document
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is an authentication scheme
+type SecurityScheme struct {
+	Type             string      `json:"type"`
+	Description      string      `json:"description,omitempty"`
+	Scheme           string      `json:"scheme,omitempty"`
+	BearerFormat     string      `json:"bearerFormat,omitempty"`
+	In               string      `json:"in,omitempty"`
+	Name             string      `json:"name,omitempty"`
+	OpenIDConnectURL string      `json:"openIdConnectUrl,omitempty"`
+	Flows            *OAuthFlows `json:"flows,omitempty"`
+}
+
+// OAuthFlows holds the supported OAuth2 flows of a security scheme
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow configures one OAuth2 flow
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// MarshalJSON serializes the document with its extensions
+func (s *Spec) MarshalJSON() ([]byte, error) {
+	type plain Spec
+	return marshalWithExtensions((*plain)(s), s.Extensions)
+}
+
+// MarshalJSON serializes the operation with its extensions
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	type plain Operation
+	return marshalWithExtensions((*plain)(o), o.Extensions)
+}
+
+// MarshalJSON serializes the parameter with its extensions
+func (p *Parameter) MarshalJSON() ([]byte, error) {
+	type plain Parameter
+	return marshalWithExtensions((*plain)(p), p.Extensions)
+}
+
+// MarshalJSON serializes the request body with its extensions
+func (r *RequestBody) MarshalJSON() ([]byte, error) {
+	type plain RequestBody
+	return marshalWithExtensions((*plain)(r), r.Extensions)
+}
+
+// MarshalJSON serializes the response with its extensions
+func (r *Response) MarshalJSON() ([]byte, error) {
+	type plain Response
+	return marshalWithExtensions((*plain)(r), r.Extensions)
+}
+
+// MarshalJSON serializes the schema with its extensions
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	return marshalWithExtensions((*plain)(s), s.Extensions)
+}
+
+// marshalWithExtensions serializes an object and appends its extensions as further keys, sorted by name
+func marshalWithExtensions(object interface{}, extensions map[string]interface{}) ([]byte, error) {
+	data, err := encodeJSON(object)
+	if err != nil || len(extensions) == 0 {
+		return data, err
+	}
+
+	extra, err := encodeJSON(extensions)
+	if err != nil {
+		return nil, err
+	}
+	return joinObjects(data, extra), nil
+}
+
+// joinObjects appends the keys of one serialized JSON object to another
+func joinObjects(object, extra []byte) []byte {
+	switch {
+	case bytes.Equal(extra, []byte("{}")):
+		return object
+	case bytes.Equal(object, []byte("{}")):
+		return extra
+	}
+
+	merged := make([]byte, 0, len(object)+len(extra))
+	merged = append(merged, object[:len(object)-1]...)
+	merged = append(merged, ',')
+	return append(merged, extra[1:]...)
+}
+
+// encodeJSON serializes a value as compact JSON without escaping HTML characters, which descriptions often contain
+func encodeJSON(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"bytes"
+	"sort"
+)
+
+// OrderedMap is a string-keyed map that serializes its entries in insertion order, so objects
+// whose key order carries meaning, such as paths and schema properties, come out the same way every run
+type OrderedMap[V any] struct {
+	keys   []string
+	values map[string]V
+}
+
+// NewOrderedMap creates an empty ordered map
+func NewOrderedMap[V any]() *OrderedMap[V] {
+	return &OrderedMap[V]{values: make(map[string]V)}
+}
+
+// Set adds or replaces an entry. A replaced entry keeps its position.
+func (m *OrderedMap[V]) Set(key string, value V) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Get returns the value stored under a key
+func (m *OrderedMap[V]) Get(key string) (V, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Keys returns the keys in order
+func (m *OrderedMap[V]) Keys() []string {
+	return m.keys
+}
+
+// Len returns the number of entries
+func (m *OrderedMap[V]) Len() int {
+	return len(m.keys)
+}
+
+// Sort orders the entries by key
+func (m *OrderedMap[V]) Sort() {
+	sort.Strings(m.keys)
+}
+
+// MarshalJSON serializes the entries as a JSON object in order
+func (m *OrderedMap[V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := encodeJSON(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := encodeJSON(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
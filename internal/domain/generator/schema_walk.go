@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// schemaSlot is a place in the document that holds a schema
+type schemaSlot struct {
+	schema   **Schema
+	pointer  string // JSON pointer of the schema
+	name     string // component name suggested by the context
+	semantic bool   // the name comes from a property, header or parameter rather than an operation
+	root     bool   // the schema is a component schema
+}
+
+// walkSchemas calls visit for every schema of the document in document order, parents before the schemas
+// nested in them. A visit may replace the schema in its slot; returning false skips the nested schemas.
+func walkSchemas(spec *Spec, visit func(schemaSlot) bool) {
+	for _, path := range spec.Paths.Keys() {
+		pathItem, _ := spec.Paths.Get(path)
+		walkPathItem(pathItem, "#/paths/"+pointerEscaper.Replace(path), visit)
+	}
+	if spec.Webhooks != nil {
+		for _, name := range spec.Webhooks.Keys() {
+			pathItem, _ := spec.Webhooks.Get(name)
+			walkPathItem(pathItem, "#/webhooks/"+pointerEscaper.Replace(name), visit)
+		}
+	}
+	if spec.Components != nil {
+		for _, name := range sortedKeys(spec.Components.Schemas) {
+			component := spec.Components.Schemas[name]
+			walkSchema(schemaSlot{
+				schema:  &component,
+				pointer: componentSchemaPrefix + pointerEscaper.Replace(name),
+				name:    name,
+				root:    true,
+			}, visit)
+			spec.Components.Schemas[name] = component
+		}
+	}
+}
+
+// methodOperation is an operation of a path item with its lowercase HTTP method
+type methodOperation struct {
+	method    string
+	operation *Operation
+}
+
+// operations returns the operations of a path item in the canonical method order
+func (p *PathItem) operations() []methodOperation {
+	var present []methodOperation
+	for _, entry := range []methodOperation{
+		{"get", p.Get}, {"put", p.Put}, {"post", p.Post}, {"delete", p.Delete},
+		{"options", p.Options}, {"head", p.Head}, {"patch", p.Patch}, {"trace", p.Trace},
+	} {
+		if entry.operation != nil {
+			present = append(present, entry)
+		}
+	}
+	return present
+}
+
+// walkPathItem walks the operations of a path item
+func walkPathItem(pathItem *PathItem, pointer string, visit func(schemaSlot) bool) {
+	for _, entry := range pathItem.operations() {
+		walkOperation(entry.operation, pointer+"/"+entry.method, visit)
+	}
+}
+
+// walkOperation walks the parameter, body, header and callback schemas of an operation
+func walkOperation(op *Operation, pointer string, visit func(schemaSlot) bool) {
+	base := componentName(op.OperationID)
+
+	for i, parameter := range op.Parameters {
+		walkSchema(schemaSlot{
+			schema:   &parameter.Schema,
+			pointer:  pointer + "/parameters/" + strconv.Itoa(i) + "/schema",
+			name:     componentName(parameter.Name),
+			semantic: true,
+		}, visit)
+	}
+	if op.RequestBody != nil {
+		walkContent(op.RequestBody.Content, pointer+"/requestBody/content", base+"Request", visit)
+	}
+
+	for _, code := range sortedKeys(op.Responses) {
+		response := op.Responses[code]
+		responsePointer := pointer + "/responses/" + pointerEscaper.Replace(code)
+		for _, name := range sortedKeys(response.Headers) {
+			walkSchema(schemaSlot{
+				schema:   &response.Headers[name].Schema,
+				pointer:  responsePointer + "/headers/" + pointerEscaper.Replace(name) + "/schema",
+				name:     componentName(name),
+				semantic: true,
+			}, visit)
+		}
+		name := base + "Response"
+		if !strings.HasPrefix(code, "2") {
+			name += componentName(code)
+		}
+		walkContent(response.Content, responsePointer+"/content", name, visit)
+	}
+
+	for _, name := range sortedKeys(op.Callbacks) {
+		callback := op.Callbacks[name]
+		for _, expression := range sortedKeys(callback) {
+			walkPathItem(callback[expression],
+				pointer+"/callbacks/"+pointerEscaper.Replace(name)+"/"+pointerEscaper.Replace(expression), visit)
+		}
+	}
+}
+
+// walkContent walks the schemas of a content map
+func walkContent(content map[string]*MediaType, pointer, name string, visit func(schemaSlot) bool) {
+	for _, mediaType := range sortedKeys(content) {
+		walkSchema(schemaSlot{
+			schema:  &content[mediaType].Schema,
+			pointer: pointer + "/" + pointerEscaper.Replace(mediaType) + "/schema",
+			name:    name,
+		}, visit)
+	}
+}
+
+// walkSchema visits a schema slot, then walks the schemas nested in whatever the slot holds afterwards
+func walkSchema(slot schemaSlot, visit func(schemaSlot) bool) {
+	if *slot.schema == nil || !visit(slot) {
+		return
+	}
+
+	s := *slot.schema
+	if s.Properties != nil {
+		for _, property := range s.Properties.Keys() {
+			child, _ := s.Properties.Get(property)
+			walkSchema(schemaSlot{
+				schema:   &child,
+				pointer:  slot.pointer + "/properties/" + pointerEscaper.Replace(property),
+				name:     componentName(property),
+				semantic: true,
+			}, visit)
+			s.Properties.Set(property, child)
+		}
+	}
+	if s.Items != nil {
+		walkSchema(schemaSlot{schema: &s.Items, pointer: slot.pointer + "/items", name: singular(slot.name), semantic: true}, visit)
+	}
+	for _, composition := range []struct {
+		key     string
+		schemas []*Schema
+	}{
+		{"allOf", s.AllOf},
+		{"oneOf", s.OneOf},
+		{"anyOf", s.AnyOf},
+	} {
+		for i := range composition.schemas {
+			walkSchema(schemaSlot{
+				schema:  &composition.schemas[i],
+				pointer: slot.pointer + "/" + composition.key + "/" + strconv.Itoa(i),
+				name:    slot.name + strconv.Itoa(i+1),
+			}, visit)
+		}
+	}
+}
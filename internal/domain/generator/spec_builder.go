@@ -0,0 +1,431 @@
+package generator
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/internal/domain/parser"
+)
+
+// specBuilder assembles the OpenAPI model while visiting a parsed document.
+// Operations are converted whole when their endpoint or webhook is visited, so the
+// parameter, body, response and schema visits that follow are not needed.
+type specBuilder struct {
+	parser.BaseVisitor
+	spec    *Spec
+	schemes map[string]bool // declared security scheme names
+}
+
+// buildSpec converts a parsed document into an OpenAPI model. Paths and webhooks keep their source
+// order unless pathOrder is PathOrderSorted.
+func buildSpec(ctx context.Context, doc *parser.Document, pathOrder string) (*Spec, error) {
+	builder := &specBuilder{
+		spec: &Spec{
+			OpenAPI: openAPIVersion,
+			Info: Info{
+				Title:       "Generated API",
+				Version:     getVersionOrDefault(""),
+				Description: getDescriptionOrDefault(""),
+			},
+			Paths: NewOrderedMap[*PathItem](),
+		},
+	}
+	if err := doc.Accept(ctx, builder); err != nil {
+		return nil, err
+	}
+
+	if pathOrder == PathOrderSorted {
+		builder.spec.Paths.Sort()
+		if builder.spec.Webhooks != nil {
+			builder.spec.Webhooks.Sort()
+		}
+	}
+	return builder.spec, nil
+}
+
+// VisitDocument converts the document-level tags, security requirements and extensions
+func (b *specBuilder) VisitDocument(ctx context.Context, doc *parser.Document) error {
+	for _, tag := range doc.Tags {
+		b.spec.Tags = append(b.spec.Tags, Tag{Name: tag.Name, Description: tag.Description})
+	}
+	b.schemes = make(map[string]bool, len(doc.SecuritySchemes))
+	for _, scheme := range doc.SecuritySchemes {
+		b.schemes[scheme.Name] = true
+	}
+	if requirements := b.securityRequirements(doc.Security); len(requirements) > 0 {
+		b.spec.Security = requirements
+	}
+	b.spec.Extensions = doc.Extensions
+	return nil
+}
+
+// VisitFrontmatter converts the API title, version, description and servers
+func (b *specBuilder) VisitFrontmatter(ctx context.Context, frontmatter *parser.Frontmatter) error {
+	if frontmatter.Title != "" {
+		b.spec.Info.Title = frontmatter.Title
+	}
+	b.spec.Info.Version = getVersionOrDefault(frontmatter.Version)
+	b.spec.Info.Description = getDescriptionOrDefault(frontmatter.Description)
+	for _, server := range frontmatter.Servers {
+		b.spec.Servers = append(b.spec.Servers, Server{URL: server.URL, Description: server.Description})
+	}
+	return nil
+}
+
+// VisitEndpoint adds the endpoint operation to its path item
+func (b *specBuilder) VisitEndpoint(ctx context.Context, endpoint *parser.Endpoint) error {
+	pathItem, ok := b.spec.Paths.Get(endpoint.Path)
+	if !ok {
+		pathItem = &PathItem{}
+		b.spec.Paths.Set(endpoint.Path, pathItem)
+	}
+	pathItem.setOperation(endpoint.Method, b.operation(endpoint))
+	return nil
+}
+
+// VisitWebhook adds the webhook operation to the path item named after the webhook
+func (b *specBuilder) VisitWebhook(ctx context.Context, webhook *parser.Webhook) error {
+	if webhook.Operation == nil {
+		return nil
+	}
+	if b.spec.Webhooks == nil {
+		b.spec.Webhooks = NewOrderedMap[*PathItem]()
+	}
+	pathItem, ok := b.spec.Webhooks.Get(webhook.Name)
+	if !ok {
+		pathItem = &PathItem{}
+		b.spec.Webhooks.Set(webhook.Name, pathItem)
+	}
+	pathItem.setOperation(webhook.Operation.Method, b.operation(webhook.Operation))
+	return nil
+}
+
+// VisitComponent adds a reusable schema
+func (b *specBuilder) VisitComponent(ctx context.Context, component *parser.Component) error {
+	if component.Schema == nil {
+		return nil
+	}
+	components := b.components()
+	if components.Schemas == nil {
+		components.Schemas = make(map[string]*Schema)
+	}
+	components.Schemas[component.Name] = schema(component.Schema)
+	return nil
+}
+
+// VisitSecurityScheme adds a security scheme
+func (b *specBuilder) VisitSecurityScheme(ctx context.Context, scheme *parser.SecurityScheme) error {
+	components := b.components()
+	if components.SecuritySchemes == nil {
+		components.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+	components.SecuritySchemes[scheme.Name] = securityScheme(scheme)
+	return nil
+}
+
+// components returns the components object, creating it on first use
+func (b *specBuilder) components() *Components {
+	if b.spec.Components == nil {
+		b.spec.Components = &Components{}
+	}
+	return b.spec.Components
+}
+
+// setOperation stores an operation under its HTTP method. Methods OpenAPI has no field for
+// are reported during validation and left out.
+func (p *PathItem) setOperation(method string, op *Operation) {
+	switch strings.ToUpper(method) {
+	case "GET":
+		p.Get = op
+	case "PUT":
+		p.Put = op
+	case "POST":
+		p.Post = op
+	case "DELETE":
+		p.Delete = op
+	case "OPTIONS":
+		p.Options = op
+	case "HEAD":
+		p.Head = op
+	case "PATCH":
+		p.Patch = op
+	case "TRACE":
+		p.Trace = op
+	}
+}
+
+// operation converts an endpoint, webhook or callback operation
+func (b *specBuilder) operation(endpoint *parser.Endpoint) *Operation {
+	op := &Operation{
+		Tags:        endpoint.Tags,
+		Summary:     getEndpointSummary(endpoint),
+		OperationID: endpoint.OperationID,
+		Responses:   make(map[string]*Response),
+		Deprecated:  endpoint.Deprecated != nil,
+		Extensions:  deprecationExtensions(endpoint.Deprecated, endpoint.Extensions),
+	}
+	if endpoint.Description != op.Summary {
+		op.Description = endpoint.Description
+	}
+
+	for _, p := range endpoint.Parameters {
+		op.Parameters = append(op.Parameters, parameter(p))
+	}
+	if endpoint.RequestBody != nil {
+		op.RequestBody = &RequestBody{
+			Description: endpoint.RequestBody.Description,
+			Content:     content(endpoint.RequestBody.Content, endpoint.RequestBody.Encoding),
+			Required:    endpoint.RequestBody.Required,
+			Extensions:  endpoint.RequestBody.Extensions,
+		}
+	}
+
+	for _, r := range endpoint.Responses {
+		op.Responses[r.StatusCode] = response(r)
+	}
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = &Response{Description: "Success"}
+	}
+
+	for _, callback := range endpoint.Callbacks {
+		if callback.Operation == nil {
+			continue
+		}
+		if op.Callbacks == nil {
+			op.Callbacks = make(map[string]Callback)
+		}
+		expressions := op.Callbacks[callback.Name]
+		if expressions == nil {
+			expressions = make(Callback)
+			op.Callbacks[callback.Name] = expressions
+		}
+		pathItem := expressions[callback.Expression]
+		if pathItem == nil {
+			pathItem = &PathItem{}
+			expressions[callback.Expression] = pathItem
+		}
+		pathItem.setOperation(callback.Operation.Method, b.operation(callback.Operation))
+	}
+
+	// An empty list marks a public operation; requirements that all name undeclared schemes leave the
+	// document requirements in effect rather than making the operation public
+	if endpoint.Security != nil {
+		requirements := b.securityRequirements(endpoint.Security)
+		if len(requirements) > 0 || len(endpoint.Security) == 0 {
+			op.Security = &requirements
+		}
+	}
+	return op
+}
+
+// parameter converts an operation parameter
+func parameter(p *parser.Parameter) *Parameter {
+	return &Parameter{
+		Name:        p.Name,
+		In:          p.In,
+		Description: p.Description,
+		Required:    p.Required,
+		Deprecated:  p.Deprecated != nil,
+		Schema:      schema(p.Schema),
+		Example:     p.Example,
+		Extensions:  deprecationExtensions(p.Deprecated, p.Extensions),
+	}
+}
+
+// response converts a response, defaulting the description that OpenAPI requires
+func response(r *parser.Response) *Response {
+	converted := &Response{
+		Description: r.Description,
+		Content:     content(r.Content, nil),
+		Extensions:  r.Extensions,
+	}
+	if converted.Description == "" {
+		converted.Description = "Response " + r.StatusCode
+	}
+	for name, header := range r.Headers {
+		if converted.Headers == nil {
+			converted.Headers = make(map[string]*Header)
+		}
+		converted.Headers[name] = &Header{
+			Description: header.Description,
+			Schema:      &Schema{Type: header.Type},
+			Example:     header.Example,
+		}
+	}
+	return converted
+}
+
+// content converts a content map keyed by media type. Encoding entries only apply to multipart media types.
+func content(schemas map[string]*parser.Schema, encoding map[string]*parser.Encoding) map[string]*MediaType {
+	if len(schemas) == 0 {
+		return nil
+	}
+
+	converted := make(map[string]*MediaType, len(schemas))
+	for mediaType, s := range schemas {
+		media := &MediaType{Schema: schema(s)}
+		if len(encoding) > 0 && strings.HasPrefix(mediaType, "multipart/") {
+			media.Encoding = make(map[string]*Encoding, len(encoding))
+			for name, fieldEncoding := range encoding {
+				media.Encoding[name] = &Encoding{ContentType: fieldEncoding.ContentType}
+			}
+		}
+		converted[mediaType] = media
+	}
+	return converted
+}
+
+// schema converts a schema tree. A reference keeps only its deprecation and extensions beside "$ref".
+func schema(s *parser.Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+
+	converted := &Schema{
+		Deprecated: s.Deprecated != nil,
+		Extensions: deprecationExtensions(s.Deprecated, s.Extensions),
+	}
+	if s.Ref != "" {
+		converted.Ref = s.Ref
+		return converted
+	}
+
+	if s.Type != "" {
+		converted.Type = s.Type
+		if s.Nullable {
+			converted.Type = []string{s.Type, "null"}
+		}
+	}
+	converted.Format = s.Format
+	converted.Description = s.Description
+	converted.Pattern = s.Pattern
+	converted.ContentMediaType = s.ContentMediaType
+	converted.ContentEncoding = s.ContentEncoding
+	converted.Minimum = s.Minimum
+	converted.Maximum = s.Maximum
+	if s.ExclusiveMinimum != nil {
+		converted.ExclusiveMinimum = *s.ExclusiveMinimum
+	}
+	if s.ExclusiveMaximum != nil {
+		converted.ExclusiveMaximum = *s.ExclusiveMaximum
+	}
+	converted.MultipleOf = s.MultipleOf
+	converted.MinLength = s.MinLength
+	converted.MaxLength = s.MaxLength
+	converted.MinItems = s.MinItems
+	converted.MaxItems = s.MaxItems
+	converted.MinProperties = s.MinProperties
+	converted.MaxProperties = s.MaxProperties
+	converted.UniqueItems = s.UniqueItems
+	converted.ReadOnly = s.ReadOnly
+	converted.WriteOnly = s.WriteOnly
+	converted.Enum = s.Enum
+	converted.Const = s.Const
+	converted.Default = s.Default
+	if s.Example != nil {
+		converted.Examples = []interface{}{s.Example}
+	}
+	converted.Required = s.Required
+	converted.Items = schema(s.Items)
+
+	if len(s.Properties) > 0 {
+		converted.Properties = NewOrderedMap[*Schema]()
+		for _, name := range s.PropertyNames() {
+			converted.Properties.Set(name, schema(s.Properties[name]))
+		}
+	}
+	converted.AllOf = schemas(s.AllOf)
+	converted.OneOf = schemas(s.OneOf)
+	converted.AnyOf = schemas(s.AnyOf)
+	return converted
+}
+
+// schemas converts a list of composed schemas
+func schemas(list []*parser.Schema) []*Schema {
+	if len(list) == 0 {
+		return nil
+	}
+	converted := make([]*Schema, len(list))
+	for i, s := range list {
+		converted[i] = schema(s)
+	}
+	return converted
+}
+
+// securityRequirements converts security requirements, keeping an empty list empty so it marks a public operation.
+// Requirements naming an undeclared scheme are reported during parsing and left out, since the document
+// could not reference them.
+func (b *specBuilder) securityRequirements(requirements []*parser.SecurityRequirement) []SecurityRequirement {
+	converted := make([]SecurityRequirement, 0, len(requirements))
+	for _, requirement := range requirements {
+		if !b.schemes[requirement.Scheme] {
+			continue
+		}
+		scopes := requirement.Scopes
+		if scopes == nil {
+			scopes = []string{}
+		}
+		converted = append(converted, SecurityRequirement{requirement.Scheme: scopes})
+	}
+	return converted
+}
+
+// securityScheme converts a security scheme, keeping the fields that apply to its type
+func securityScheme(scheme *parser.SecurityScheme) *SecurityScheme {
+	converted := &SecurityScheme{Type: scheme.Type, Description: scheme.Description}
+
+	switch scheme.Type {
+	case "http":
+		converted.Scheme = scheme.Scheme
+		converted.BearerFormat = scheme.BearerFormat
+	case "apiKey":
+		converted.In = scheme.In
+		converted.Name = scheme.ParameterName
+	case "openIdConnect":
+		converted.OpenIDConnectURL = scheme.OpenIDConnectURL
+	case "oauth2":
+		converted.Flows = &OAuthFlows{}
+		for _, flow := range scheme.Flows {
+			scopes := flow.Scopes
+			if scopes == nil {
+				scopes = map[string]string{}
+			}
+			convertedFlow := &OAuthFlow{
+				AuthorizationURL: flow.AuthorizationURL,
+				TokenURL:         flow.TokenURL,
+				RefreshURL:       flow.RefreshURL,
+				Scopes:           scopes,
+			}
+			switch flow.Type {
+			case "implicit":
+				converted.Flows.Implicit = convertedFlow
+			case "password":
+				converted.Flows.Password = convertedFlow
+			case "clientCredentials":
+				converted.Flows.ClientCredentials = convertedFlow
+			case "authorizationCode":
+				converted.Flows.AuthorizationCode = convertedFlow
+			}
+		}
+	}
+	return converted
+}
+
+// deprecationExtensions returns the extensions of a node with the "x-sunset" and "x-replaced-by"
+// extensions of its deprecation added
+func deprecationExtensions(deprecation *parser.Deprecation, extensions map[string]interface{}) map[string]interface{} {
+	if deprecation == nil || (deprecation.Sunset == "" && deprecation.ReplacedBy == "") {
+		return extensions
+	}
+
+	merged := make(map[string]interface{}, len(extensions)+2)
+	maps.Copy(merged, extensions)
+	if deprecation.Sunset != "" {
+		merged["x-sunset"] = deprecation.Sunset
+	}
+	if deprecation.ReplacedBy != "" {
+		merged["x-replaced-by"] = deprecation.ReplacedBy
+	}
+	return merged
+}
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_SecurityRequirements(t *testing.T) {
+	content := "---\n" +
+		"title: Users API\n" +
+		"securitySchemes:\n" +
+		"  bearerAuth: bearer\n" +
+		"security: bearerAuth\n" +
+		"---\n\n" +
+		"## GET /users\n\n" +
+		"List users.\n\n" +
+		"## POST /users\n\n" +
+		"**Auth:** missingAuth\n\n" +
+		"## GET /health\n\n" +
+		"**Auth:** none\n\n" +
+		"## DELETE /users\n\n" +
+		"**Auth:** bearerAuth or missingAuth\n"
+
+	tree := generateTree(t, content, Config{})
+
+	bearer := []interface{}{map[string]interface{}{"bearerAuth": []interface{}{}}}
+	assert.Equal(t, bearer, lookup(t, tree, "security"))
+	assert.Equal(t, "http", lookup(t, tree, "components", "securitySchemes", "bearerAuth", "type"))
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		security interface{} // nil when the operation inherits the document requirements
+	}{
+		{name: "inherits the document requirements", method: "get", path: "/users"},
+		{name: "drops an undeclared scheme and inherits", method: "post", path: "/users"},
+		{name: "keeps an explicit public operation", method: "get", path: "/health", security: []interface{}{}},
+		{name: "keeps only the declared alternatives", method: "delete", path: "/users", security: bearer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := lookup(t, tree, "paths", tt.path, tt.method).(map[string]interface{})
+			security, ok := operation["security"]
+			if tt.security == nil {
+				assert.False(t, ok, "unexpected security %v", security)
+				return
+			}
+			assert.Equal(t, tt.security, security)
+		})
+	}
+}
@@ -0,0 +1,572 @@
+package generator
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// swaggerVersion is the version of documents generated for Target20
+const swaggerVersion = "2.0"
+
+// definitionsPrefix is the reference prefix of Swagger 2.0 schema definitions
+const definitionsPrefix = "#/definitions/"
+
+// Swagger is a Swagger 2.0 document, converted from an OpenAPI document. Fields follow the order of the specification.
+type Swagger struct {
+	Swagger             string                            `json:"swagger"`
+	Info                Info                              `json:"info"`
+	Host                string                            `json:"host,omitempty"`
+	BasePath            string                            `json:"basePath,omitempty"`
+	Schemes             []string                          `json:"schemes,omitempty"`
+	Paths               *OrderedMap[*SwaggerPathItem]     `json:"paths"` // key: path template
+	Definitions         map[string]*Schema                `json:"definitions,omitempty"`
+	SecurityDefinitions map[string]*SwaggerSecurityScheme `json:"securityDefinitions,omitempty"`
+	Security            []SecurityRequirement             `json:"security,omitempty"`
+	Tags                []Tag                             `json:"tags,omitempty"`
+	Extensions          map[string]interface{}            `json:"-"`
+}
+
+// SwaggerPathItem holds the operations of one path
+type SwaggerPathItem struct {
+	Get     *SwaggerOperation `json:"get,omitempty"`
+	Put     *SwaggerOperation `json:"put,omitempty"`
+	Post    *SwaggerOperation `json:"post,omitempty"`
+	Delete  *SwaggerOperation `json:"delete,omitempty"`
+	Options *SwaggerOperation `json:"options,omitempty"`
+	Head    *SwaggerOperation `json:"head,omitempty"`
+	Patch   *SwaggerOperation `json:"patch,omitempty"`
+}
+
+// SwaggerOperation is a single API operation on a path item
+type SwaggerOperation struct {
+	Tags        []string                    `json:"tags,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	OperationID string                      `json:"operationId,omitempty"`
+	Consumes    []string                    `json:"consumes,omitempty"`
+	Produces    []string                    `json:"produces,omitempty"`
+	Parameters  []*SwaggerParameter         `json:"parameters,omitempty"`
+	Responses   map[string]*SwaggerResponse `json:"responses"` // key: status code
+	Deprecated  bool                        `json:"deprecated,omitempty"`
+	Security    *[]SecurityRequirement      `json:"security,omitempty"` // nil inherits the document requirements, empty is public
+	Extensions  map[string]interface{}      `json:"-"`
+}
+
+// SwaggerParameter is an operation parameter. Body parameters carry a schema, the others describe a simple value.
+type SwaggerParameter struct {
+	Name             string                 `json:"name"`
+	In               string                 `json:"in"`
+	Description      string                 `json:"description,omitempty"`
+	Required         bool                   `json:"required,omitempty"`
+	Schema           *Schema                `json:"schema,omitempty"`
+	Type             interface{}            `json:"type,omitempty"`
+	Format           string                 `json:"format,omitempty"`
+	Items            *Schema                `json:"items,omitempty"`
+	Default          interface{}            `json:"default,omitempty"`
+	Maximum          *float64               `json:"maximum,omitempty"`
+	ExclusiveMaximum interface{}            `json:"exclusiveMaximum,omitempty"`
+	Minimum          *float64               `json:"minimum,omitempty"`
+	ExclusiveMinimum interface{}            `json:"exclusiveMinimum,omitempty"`
+	MaxLength        *int                   `json:"maxLength,omitempty"`
+	MinLength        *int                   `json:"minLength,omitempty"`
+	Pattern          string                 `json:"pattern,omitempty"`
+	MaxItems         *int                   `json:"maxItems,omitempty"`
+	MinItems         *int                   `json:"minItems,omitempty"`
+	UniqueItems      bool                   `json:"uniqueItems,omitempty"`
+	Enum             []interface{}          `json:"enum,omitempty"`
+	MultipleOf       *float64               `json:"multipleOf,omitempty"`
+	Extensions       map[string]interface{} `json:"-"`
+}
+
+// SwaggerResponse is the response of an operation for one status code
+type SwaggerResponse struct {
+	Description string                    `json:"description"`
+	Schema      *Schema                   `json:"schema,omitempty"`
+	Headers     map[string]*SwaggerHeader `json:"headers,omitempty"`
+	Extensions  map[string]interface{}    `json:"-"`
+}
+
+// SwaggerHeader is a response header
+type SwaggerHeader struct {
+	Description string      `json:"description,omitempty"`
+	Type        interface{} `json:"type"`
+	Format      string      `json:"format,omitempty"`
+	Items       *Schema     `json:"items,omitempty"`
+}
+
+// SwaggerSecurityScheme is an authentication scheme
+type SwaggerSecurityScheme struct {
+	Type             string            `json:"type"`
+	Description      string            `json:"description,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	In               string            `json:"in,omitempty"`
+	Flow             string            `json:"flow,omitempty"`
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes,omitempty"`
+}
+
+// MarshalJSON serializes the document with its extensions
+func (s *Swagger) MarshalJSON() ([]byte, error) {
+	type plain Swagger
+	return marshalWithExtensions((*plain)(s), s.Extensions)
+}
+
+// MarshalJSON serializes the operation with its extensions
+func (o *SwaggerOperation) MarshalJSON() ([]byte, error) {
+	type plain SwaggerOperation
+	return marshalWithExtensions((*plain)(o), o.Extensions)
+}
+
+// MarshalJSON serializes the parameter with its extensions
+func (p *SwaggerParameter) MarshalJSON() ([]byte, error) {
+	type plain SwaggerParameter
+	return marshalWithExtensions((*plain)(p), p.Extensions)
+}
+
+// MarshalJSON serializes the response with its extensions
+func (r *SwaggerResponse) MarshalJSON() ([]byte, error) {
+	type plain SwaggerResponse
+	return marshalWithExtensions((*plain)(r), r.Extensions)
+}
+
+// swagger builds a Swagger 2.0 document from the document already converted to OpenAPI 3.0
+func (c *conversion) swagger() *Swagger {
+	spec := c.spec
+	swagger := &Swagger{
+		Swagger:    swaggerVersion,
+		Info:       spec.Info,
+		Tags:       spec.Tags,
+		Paths:      NewOrderedMap[*SwaggerPathItem](),
+		Extensions: spec.Extensions,
+	}
+	c.serversTo20(swagger)
+
+	walkSchemas(spec, func(slot schemaSlot) bool {
+		c.schemaTo20(*slot.schema, slot.pointer)
+		return true
+	})
+
+	dropped := make(map[string]bool)
+	if spec.Components != nil {
+		swagger.Definitions = spec.Components.Schemas
+		for _, name := range sortedKeys(spec.Components.SecuritySchemes) {
+			scheme := c.securitySchemeTo20(spec.Components.SecuritySchemes[name], "#/components/securitySchemes/"+pointerEscaper.Replace(name))
+			if scheme == nil {
+				dropped[name] = true
+				continue
+			}
+			if swagger.SecurityDefinitions == nil {
+				swagger.SecurityDefinitions = make(map[string]*SwaggerSecurityScheme)
+			}
+			swagger.SecurityDefinitions[name] = scheme
+		}
+	}
+	if requirements := c.securityTo20(&spec.Security, dropped, "#/security"); requirements != nil {
+		swagger.Security = *requirements
+	}
+
+	for _, path := range spec.Paths.Keys() {
+		pathItem, _ := spec.Paths.Get(path)
+		swagger.Paths.Set(path, c.pathItemTo20(pathItem, "#/paths/"+pointerEscaper.Replace(path), dropped))
+	}
+	return swagger
+}
+
+// serversTo20 sets the host, base path and schemes from the servers. Swagger 2.0 has a single host and
+// base path, so servers that differ in more than their scheme are left out.
+func (c *conversion) serversTo20(swagger *Swagger) {
+	accepted := false
+	for i, server := range c.spec.Servers {
+		pointer := "#/servers/" + strconv.Itoa(i)
+		if strings.Contains(server.URL, "{") {
+			c.warn(pointer, "server variables need OpenAPI 3; the server is left out")
+			continue
+		}
+		target, err := url.Parse(server.URL)
+		if err != nil {
+			c.warn(pointer, "server URL %q is invalid and left out", server.URL)
+			continue
+		}
+
+		basePath := target.Path
+		if len(basePath) > 1 {
+			basePath = strings.TrimSuffix(basePath, "/")
+		}
+		if !accepted {
+			swagger.Host, swagger.BasePath = target.Host, basePath
+			accepted = true
+		} else if target.Host != swagger.Host || basePath != swagger.BasePath {
+			c.warn(pointer, "Swagger 2.0 has a single host and base path; the server %s is left out", server.URL)
+			continue
+		}
+		if target.Scheme != "" && !slices.Contains(swagger.Schemes, target.Scheme) {
+			swagger.Schemes = append(swagger.Schemes, target.Scheme)
+		}
+	}
+}
+
+// schemaTo20 removes the keywords a Swagger 2.0 schema does not have and points references at definitions
+func (c *conversion) schemaTo20(s *Schema, pointer string) {
+	if strings.HasPrefix(s.Ref, componentSchemaPrefix) {
+		s.Ref = definitionsPrefix + strings.TrimPrefix(s.Ref, componentSchemaPrefix)
+	}
+	if s.Nullable {
+		s.Extensions = withExtension(s.Extensions, "x-nullable", true)
+		s.Nullable = false
+	}
+	if len(s.OneOf) > 0 {
+		c.warn(pointer, "oneOf needs OpenAPI 3 and is left out")
+		s.OneOf = nil
+	}
+	if len(s.AnyOf) > 0 {
+		c.warn(pointer, "anyOf needs OpenAPI 3 and is left out")
+		s.AnyOf = nil
+	}
+	if s.WriteOnly {
+		c.warn(pointer, "writeOnly needs OpenAPI 3 and is left out")
+		s.WriteOnly = false
+	}
+	if s.Deprecated {
+		c.warn(pointer, "deprecated schemas need OpenAPI 3; the flag is left out")
+		s.Deprecated = false
+	}
+}
+
+// pathItemTo20 converts the operations of a path item
+func (c *conversion) pathItemTo20(pathItem *PathItem, pointer string, dropped map[string]bool) *SwaggerPathItem {
+	converted := &SwaggerPathItem{}
+	for _, entry := range pathItem.operations() {
+		operationPointer := pointer + "/" + entry.method
+		op := c.operationTo20(entry.operation, operationPointer, dropped)
+		switch entry.method {
+		case "get":
+			converted.Get = op
+		case "put":
+			converted.Put = op
+		case "post":
+			converted.Post = op
+		case "delete":
+			converted.Delete = op
+		case "options":
+			converted.Options = op
+		case "head":
+			converted.Head = op
+		case "patch":
+			converted.Patch = op
+		default:
+			c.warn(operationPointer, "%s operations need OpenAPI 3 and are left out", strings.ToUpper(entry.method))
+		}
+	}
+	return converted
+}
+
+// operationTo20 converts an operation, turning its request body into body or formData parameters
+func (c *conversion) operationTo20(op *Operation, pointer string, dropped map[string]bool) *SwaggerOperation {
+	converted := &SwaggerOperation{
+		Tags:        op.Tags,
+		Summary:     op.Summary,
+		Description: op.Description,
+		OperationID: op.OperationID,
+		Responses:   make(map[string]*SwaggerResponse, len(op.Responses)),
+		Deprecated:  op.Deprecated,
+		Security:    c.securityTo20(op.Security, dropped, pointer+"/security"),
+		Extensions:  op.Extensions,
+	}
+
+	for i, parameter := range op.Parameters {
+		parameterPointer := pointer + "/parameters/" + strconv.Itoa(i)
+		if parameter.In == "cookie" {
+			c.warn(parameterPointer, "cookie parameters need OpenAPI 3; %q is left out", parameter.Name)
+			continue
+		}
+		if parameter.Deprecated {
+			c.warn(parameterPointer, "deprecated parameters need OpenAPI 3; the flag is left out")
+		}
+
+		p := &SwaggerParameter{
+			Name:        parameter.Name,
+			In:          parameter.In,
+			Description: parameter.Description,
+			Required:    parameter.Required,
+			Extensions:  parameter.Extensions,
+		}
+		if parameter.Example != nil {
+			p.Extensions = withExtension(p.Extensions, "x-example", parameter.Example)
+		}
+		c.simpleValue(p, parameter.Schema, parameterPointer)
+		converted.Parameters = append(converted.Parameters, p)
+	}
+
+	if op.RequestBody != nil {
+		converted.Consumes = c.requestBodyTo20(op.RequestBody, converted, pointer+"/requestBody")
+	}
+
+	for _, code := range sortedKeys(op.Responses) {
+		response := op.Responses[code]
+		responsePointer := pointer + "/responses/" + pointerEscaper.Replace(code)
+		r := &SwaggerResponse{Description: response.Description, Extensions: response.Extensions}
+
+		for _, name := range sortedKeys(response.Headers) {
+			header := response.Headers[name]
+			headerPointer := responsePointer + "/headers/" + pointerEscaper.Replace(name)
+			if header.Example != nil {
+				c.warn(headerPointer, "header examples need OpenAPI 3 and are left out")
+			}
+			value := &SwaggerParameter{}
+			c.simpleValue(value, header.Schema, headerPointer)
+			if r.Headers == nil {
+				r.Headers = make(map[string]*SwaggerHeader)
+			}
+			r.Headers[name] = &SwaggerHeader{
+				Description: header.Description,
+				Type:        value.Type,
+				Format:      value.Format,
+				Items:       value.Items,
+			}
+		}
+
+		if len(response.Content) > 0 {
+			mediaTypes, schema := c.singleContent(response.Content, responsePointer+"/content")
+			if schema != nil && schema.Type == "string" && schema.Format == "binary" {
+				schema = &Schema{Type: "file", Description: schema.Description}
+			}
+			r.Schema = schema
+			for _, mediaType := range mediaTypes {
+				if !slices.Contains(converted.Produces, mediaType) {
+					converted.Produces = append(converted.Produces, mediaType)
+				}
+			}
+		}
+		converted.Responses[code] = r
+	}
+
+	for _, name := range sortedKeys(op.Callbacks) {
+		c.warn(pointer+"/callbacks/"+pointerEscaper.Replace(name), "callbacks need OpenAPI 3 and are left out")
+	}
+	return converted
+}
+
+// requestBodyTo20 adds the body or formData parameters of a request body and returns the media types it consumes
+func (c *conversion) requestBodyTo20(body *RequestBody, op *SwaggerOperation, pointer string) []string {
+	if len(body.Content) == 0 {
+		return nil
+	}
+	mediaTypes, schema := c.singleContent(body.Content, pointer+"/content")
+
+	if !isFormMediaType(mediaTypes[0]) {
+		if schema == nil {
+			schema = &Schema{}
+		}
+		op.Parameters = append(op.Parameters, &SwaggerParameter{
+			Name:        "body",
+			In:          "body",
+			Description: body.Description,
+			Required:    body.Required,
+			Schema:      schema,
+			Extensions:  body.Extensions,
+		})
+		return mediaTypes
+	}
+
+	form := c.resolve(schema)
+	if form == nil || form.Properties == nil {
+		c.warn(pointer, "form bodies are described field by field in Swagger 2.0; a form without listed fields is left out")
+		return mediaTypes
+	}
+	for _, name := range form.Properties.Keys() {
+		field, _ := form.Properties.Get(name)
+		fieldPointer := pointer + "/content/" + pointerEscaper.Replace(mediaTypes[0]) + "/schema/properties/" + pointerEscaper.Replace(name)
+		p := &SwaggerParameter{
+			Name:        name,
+			In:          "formData",
+			Description: field.Description,
+			Required:    slices.Contains(form.Required, name),
+		}
+		switch {
+		case isBinarySchema(field):
+			p.Type = "file"
+		case field.Type == "array" && field.Items != nil && isBinarySchema(field.Items):
+			c.warn(fieldPointer, "file lists need OpenAPI 3; a single file is described")
+			p.Type = "file"
+		default:
+			c.simpleValue(p, field, fieldPointer)
+		}
+		op.Parameters = append(op.Parameters, p)
+	}
+	return mediaTypes
+}
+
+// singleContent picks the schema of a content map, preferring JSON, and returns the media types that share it.
+// Swagger 2.0 has one schema per body, so media types with other schemas are left out.
+func (c *conversion) singleContent(content map[string]*MediaType, pointer string) ([]string, *Schema) {
+	mediaTypes := sortedKeys(content)
+	primary := mediaTypes[0]
+	for _, mediaType := range mediaTypes {
+		if strings.Contains(mediaType, "json") {
+			primary = mediaType
+			break
+		}
+	}
+
+	schema := content[primary].Schema
+	shared := []string{primary}
+	for _, mediaType := range mediaTypes {
+		if mediaType == primary {
+			continue
+		}
+		if isFormMediaType(mediaType) == isFormMediaType(primary) && schemaHash(content[mediaType].Schema) == schemaHash(schema) {
+			shared = append(shared, mediaType)
+			continue
+		}
+		c.warn(pointer+"/"+pointerEscaper.Replace(mediaType),
+			"Swagger 2.0 takes one schema per body; the %s schema differs from the %s one and is left out", mediaType, primary)
+	}
+	return shared, schema
+}
+
+// simpleValue describes a non-body parameter or header with the primitive keywords of its schema
+func (c *conversion) simpleValue(p *SwaggerParameter, s *Schema, pointer string) {
+	if s == nil {
+		p.Type = "string"
+		return
+	}
+	if s.Ref != "" || len(s.AllOf) > 0 || s.Type == "object" || s.Type == nil {
+		c.warn(pointer, "only primitive values and arrays can be described here in Swagger 2.0; a string is described instead")
+		p.Type = "string"
+		return
+	}
+
+	p.Type = s.Type
+	p.Format = s.Format
+	p.Items = s.Items
+	if s.Type == "array" && s.Items == nil {
+		c.warn(pointer, "Swagger 2.0 arrays need an item type; string items are assumed")
+		p.Items = &Schema{Type: "string"}
+	}
+	p.Default = s.Default
+	p.Maximum = s.Maximum
+	p.ExclusiveMaximum = s.ExclusiveMaximum
+	p.Minimum = s.Minimum
+	p.ExclusiveMinimum = s.ExclusiveMinimum
+	p.MaxLength = s.MaxLength
+	p.MinLength = s.MinLength
+	p.Pattern = s.Pattern
+	p.MaxItems = s.MaxItems
+	p.MinItems = s.MinItems
+	p.UniqueItems = s.UniqueItems
+	p.Enum = s.Enum
+	p.MultipleOf = s.MultipleOf
+	if nullable, ok := s.Extensions["x-nullable"]; ok {
+		p.Extensions = withExtension(p.Extensions, "x-nullable", nullable)
+	}
+}
+
+// resolve follows a reference to a definition
+func (c *conversion) resolve(s *Schema) *Schema {
+	if s == nil || !strings.HasPrefix(s.Ref, definitionsPrefix) || c.spec.Components == nil {
+		return s
+	}
+	return c.spec.Components.Schemas[strings.TrimPrefix(s.Ref, definitionsPrefix)]
+}
+
+// securitySchemeTo20 converts a security scheme, returning nil when Swagger 2.0 cannot describe it
+func (c *conversion) securitySchemeTo20(scheme *SecurityScheme, pointer string) *SwaggerSecurityScheme {
+	converted := &SwaggerSecurityScheme{Type: scheme.Type, Description: scheme.Description}
+
+	switch scheme.Type {
+	case "http":
+		switch strings.ToLower(scheme.Scheme) {
+		case "basic":
+			converted.Type = "basic"
+		case "bearer":
+			c.warn(pointer, "bearer authentication needs OpenAPI 3; it is described as an Authorization header API key")
+			converted.Type, converted.In, converted.Name = "apiKey", "header", "Authorization"
+		default:
+			c.warn(pointer, "HTTP %q authentication needs OpenAPI 3; the scheme is left out", scheme.Scheme)
+			return nil
+		}
+	case "apiKey":
+		if scheme.In == "cookie" {
+			c.warn(pointer, "cookie API keys need OpenAPI 3; the scheme is left out")
+			return nil
+		}
+		converted.In, converted.Name = scheme.In, scheme.Name
+	case "oauth2":
+		if scheme.Flows == nil {
+			c.warn(pointer, "OAuth2 schemes without flows cannot be described in Swagger 2.0; the scheme is left out")
+			return nil
+		}
+		for _, flow := range []struct {
+			name  string
+			flow  *OAuthFlow
+			label string
+		}{
+			{"implicit", scheme.Flows.Implicit, "implicit"},
+			{"password", scheme.Flows.Password, "password"},
+			{"application", scheme.Flows.ClientCredentials, "clientCredentials"},
+			{"accessCode", scheme.Flows.AuthorizationCode, "authorizationCode"},
+		} {
+			if flow.flow == nil {
+				continue
+			}
+			if converted.Flow != "" {
+				c.warn(pointer, "Swagger 2.0 takes one OAuth2 flow per scheme; the %s flow is left out", flow.label)
+				continue
+			}
+			if flow.flow.RefreshURL != "" {
+				c.warn(pointer, "refresh URLs need OpenAPI 3 and are left out")
+			}
+			converted.Flow = flow.name
+			converted.AuthorizationURL = flow.flow.AuthorizationURL
+			converted.TokenURL = flow.flow.TokenURL
+			converted.Scopes = flow.flow.Scopes
+		}
+		if converted.Flow == "" {
+			c.warn(pointer, "OAuth2 schemes without flows cannot be described in Swagger 2.0; the scheme is left out")
+			return nil
+		}
+	default:
+		c.warn(pointer, "%s security schemes need OpenAPI 3; the scheme is left out", scheme.Type)
+		return nil
+	}
+	return converted
+}
+
+// securityTo20 drops the requirements that use schemes left out of the document. When every requirement
+// of a non-empty list is dropped, the list is omitted rather than turning into the empty list of a public operation.
+func (c *conversion) securityTo20(requirements *[]SecurityRequirement, dropped map[string]bool, pointer string) *[]SecurityRequirement {
+	if requirements == nil || len(dropped) == 0 {
+		return requirements
+	}
+
+	kept := make([]SecurityRequirement, 0, len(*requirements))
+	for _, requirement := range *requirements {
+		usable := true
+		for name := range requirement {
+			if dropped[name] {
+				usable = false
+			}
+		}
+		if usable {
+			kept = append(kept, requirement)
+		}
+	}
+	if len(kept) == 0 && len(*requirements) > 0 {
+		c.warn(pointer, "every security requirement uses a scheme left out of the Swagger 2.0 document; the requirements are left out")
+		return nil
+	}
+	return &kept
+}
+
+// isFormMediaType reports whether a media type carries form fields
+func isFormMediaType(mediaType string) bool {
+	return mediaType == "application/x-www-form-urlencoded" || strings.HasPrefix(mediaType, "multipart/")
+}
+
+// isBinarySchema reports whether a schema describes raw file content
+func isBinarySchema(s *Schema) bool {
+	return s != nil && s.Type == "string" && s.Format == "binary"
+}
@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+)
+
+// Output targets, the specification version of generated documents
+const (
+	Target31 = "3.1" // OpenAPI 3.1, the default
+	Target30 = "3.0" // OpenAPI 3.0.3
+	Target20 = "2.0" // Swagger 2.0
+)
+
+// openAPI30Version is the OpenAPI version of documents generated for Target30
+const openAPI30Version = "3.0.3"
+
+// conversion rewrites an OpenAPI 3.1 document for an older target, collecting a warning for
+// everything the target cannot represent
+type conversion struct {
+	spec     *Spec
+	warnings []string
+}
+
+// convertTarget converts an OpenAPI 3.1 document to the target, returning the document to serialize
+func convertTarget(spec *Spec, target string) (interface{}, []string, error) {
+	c := &conversion{spec: spec}
+	switch target {
+	case "", Target31:
+		return spec, nil, nil
+	case Target30:
+		c.convertTo30()
+		return spec, c.warnings, nil
+	case Target20:
+		c.convertTo30()
+		return c.swagger(), c.warnings, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown target %q, use one of: %s, %s, %s", target, Target31, Target30, Target20)
+	}
+}
+
+// warn records something the target cannot represent at a JSON pointer of the 3.1 document
+func (c *conversion) warn(pointer, format string, args ...interface{}) {
+	c.warnings = append(c.warnings, pointer+": "+fmt.Sprintf(format, args...))
+}
+
+// convertTo30 rewrites the document in place as OpenAPI 3.0
+func (c *conversion) convertTo30() {
+	c.spec.OpenAPI = openAPI30Version
+	if c.spec.Webhooks != nil {
+		for _, name := range c.spec.Webhooks.Keys() {
+			c.warn("#/webhooks/"+pointerEscaper.Replace(name), "webhooks need OpenAPI 3.1 and are left out")
+		}
+		c.spec.Webhooks = nil
+	}
+
+	walkSchemas(c.spec, func(slot schemaSlot) bool {
+		*slot.schema = c.schemaTo30(*slot.schema, slot.pointer)
+		return true
+	})
+}
+
+// schemaTo30 replaces the JSON Schema 2020-12 keywords of a schema with their OpenAPI 3.0 counterparts
+func (c *conversion) schemaTo30(s *Schema, pointer string) *Schema {
+	if s.Ref != "" {
+		if !s.Deprecated && len(s.Extensions) == 0 {
+			return s
+		}
+		// OpenAPI 3.0 ignores the siblings of "$ref", so the reference moves into allOf
+		return &Schema{AllOf: []*Schema{{Ref: s.Ref}}, Deprecated: s.Deprecated, Extensions: s.Extensions}
+	}
+
+	if types, ok := s.Type.([]string); ok {
+		var named []string
+		for _, name := range types {
+			if name == "null" {
+				s.Nullable = true
+				continue
+			}
+			named = append(named, name)
+		}
+		s.Type = nil
+		if len(named) > 0 {
+			s.Type = named[0]
+		}
+		if len(named) > 1 {
+			c.warn(pointer, "type lists need OpenAPI 3.1; only %q is kept", named[0])
+		}
+	}
+
+	if len(s.Examples) > 0 {
+		s.Example = s.Examples[0]
+		if len(s.Examples) > 1 {
+			c.warn(pointer, "OpenAPI 3.0 schemas take a single example; only the first is kept")
+		}
+		s.Examples = nil
+	}
+
+	s.Minimum, s.ExclusiveMinimum = exclusiveBoundTo30(s.Minimum, s.ExclusiveMinimum, func(inclusive, exclusive float64) bool {
+		return inclusive > exclusive
+	})
+	s.Maximum, s.ExclusiveMaximum = exclusiveBoundTo30(s.Maximum, s.ExclusiveMaximum, func(inclusive, exclusive float64) bool {
+		return inclusive < exclusive
+	})
+
+	if s.Const != nil {
+		s.Enum = []interface{}{s.Const}
+		s.Const = nil
+	}
+
+	switch {
+	case s.ContentEncoding == "base64" && s.Format == "":
+		s.Format = "byte"
+	case s.ContentEncoding == "" && s.ContentMediaType != "" && s.Format == "":
+		s.Format = "binary"
+	case s.ContentEncoding != "" && s.ContentEncoding != "base64":
+		c.warn(pointer, "content encoding %q needs OpenAPI 3.1 and is left out", s.ContentEncoding)
+	}
+	s.ContentEncoding, s.ContentMediaType = "", ""
+	return s
+}
+
+// exclusiveBoundTo30 turns a numeric 3.1 exclusive bound into a 3.0 bound with an exclusive flag.
+// stricter reports whether an inclusive bound already excludes more than the exclusive one.
+func exclusiveBoundTo30(bound *float64, exclusive interface{}, stricter func(inclusive, exclusive float64) bool) (*float64, interface{}) {
+	value, ok := exclusive.(float64)
+	if !ok {
+		return bound, exclusive
+	}
+	if bound != nil && stricter(*bound, value) {
+		return bound, nil
+	}
+	return &value, true
+}
+
+// withExtension returns a copy of extensions with one more entry, leaving maps shared with the parsed document untouched
+func withExtension(extensions map[string]interface{}, name string, value interface{}) map[string]interface{} {
+	extended := make(map[string]interface{}, len(extensions)+1)
+	maps.Copy(extended, extensions)
+	extended[name] = value
+	return extended
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/internal/domain/parser"
+)
+
+// targetContent is a document using features that older targets describe differently or not at all
+const targetContent = "---\n" +
+	"title: Files API\n" +
+	"servers:\n" +
+	"  - url: https://api.example.com/v1\n" +
+	"---\n\n" +
+	"## POST /files\n\n" +
+	"**Request Body (multipart/form-data):**\n\n" +
+	"| Name | Type | Required |\n" +
+	"| --- | --- | --- |\n" +
+	"| file | file | yes |\n" +
+	"| note | string | no |\n\n" +
+	"### Callbacks\n\n" +
+	"#### onDone: POST {$request.body#/callbackUrl}\n\n" +
+	"Upload processed.\n\n" +
+	"##### Responses\n\n" +
+	"- **204** - Acknowledged\n\n" +
+	"## GET /files/{id}\n\n" +
+	"### Responses\n\n" +
+	"#### 200 - OK\n\n" +
+	"```yaml\n" +
+	"type: object\n" +
+	"properties:\n" +
+	"  name:\n" +
+	"    type: [string, \"null\"]\n" +
+	"  size:\n" +
+	"    type: integer\n" +
+	"    exclusiveMinimum: 0\n" +
+	"  owner:\n" +
+	"    $ref: Owner\n" +
+	"```\n\n" +
+	"## WEBHOOK file.deleted\n\n" +
+	"Sent when a file is deleted.\n\n" +
+	"# Components\n\n" +
+	"## Schema: Owner\n\n" +
+	"```yaml\n" +
+	"type: object\n" +
+	"properties:\n" +
+	"  id:\n" +
+	"    type: string\n" +
+	"```\n"
+
+// generateTarget generates targetContent for a target, decoded into a generic tree, with the target warnings
+func generateTarget(t *testing.T, target string) (map[string]interface{}, []string) {
+	t.Helper()
+
+	doc, err := parser.New().Parse(targetContent)
+	require.NoError(t, err)
+
+	output, report, err := New(Config{Target: target}).GenerateWithReport(context.Background(), doc, "json")
+	require.NoError(t, err)
+
+	var tree map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &tree))
+	return tree, report.Warnings
+}
+
+func TestGenerator_Target30(t *testing.T) {
+	tree, warnings := generateTarget(t, Target30)
+
+	property := func(keys ...string) []string {
+		return append([]string{
+			"paths", "/files/{id}", "get", "responses", "200", "content", "application/json", "schema", "properties",
+		}, keys...)
+	}
+
+	tests := []struct {
+		name     string
+		path     []string
+		expected interface{}
+	}{
+		{name: "version", path: []string{"openapi"}, expected: openAPI30Version},
+		{name: "type list becomes nullable", path: property("name"),
+			expected: map[string]interface{}{"type": "string", "nullable": true}},
+		{name: "numeric exclusive minimum becomes a flag", path: property("size"),
+			expected: map[string]interface{}{"type": "integer", "minimum": float64(0), "exclusiveMinimum": true}},
+		{name: "references stay in components", path: property("owner", "$ref"), expected: "#/components/schemas/Owner"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, lookup(t, tree, tt.path...))
+		})
+	}
+
+	assert.NotContains(t, tree, "webhooks")
+	assert.Contains(t, lookup(t, tree, "paths", "/files", "post"), "callbacks")
+	assert.Equal(t, []string{"#/webhooks/file.deleted: webhooks need OpenAPI 3.1 and are left out"}, warnings)
+}
+
+func TestGenerator_Target20(t *testing.T) {
+	tree, warnings := generateTarget(t, Target20)
+
+	tests := []struct {
+		name     string
+		path     []string
+		expected interface{}
+	}{
+		{name: "version", path: []string{"swagger"}, expected: "2.0"},
+		{name: "server host", path: []string{"host"}, expected: "api.example.com"},
+		{name: "server base path", path: []string{"basePath"}, expected: "/v1"},
+		{name: "server scheme", path: []string{"schemes"}, expected: []interface{}{"https"}},
+		{name: "form consumes", path: []string{"paths", "/files", "post", "consumes"},
+			expected: []interface{}{"multipart/form-data"}},
+		{name: "form fields become formData parameters", path: []string{"paths", "/files", "post", "parameters"},
+			expected: []interface{}{
+				map[string]interface{}{"name": "file", "in": "formData", "required": true, "type": "file"},
+				map[string]interface{}{"name": "note", "in": "formData", "type": "string"},
+			}},
+		{name: "nullable becomes an extension", path: []string{
+			"paths", "/files/{id}", "get", "responses", "200", "schema", "properties", "name",
+		}, expected: map[string]interface{}{"type": "string", "x-nullable": true}},
+		{name: "references point at definitions", path: []string{
+			"paths", "/files/{id}", "get", "responses", "200", "schema", "properties", "owner", "$ref",
+		}, expected: "#/definitions/Owner"},
+		{name: "components become definitions", path: []string{"definitions", "Owner", "properties", "id", "type"},
+			expected: "string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, lookup(t, tree, tt.path...))
+		})
+	}
+
+	assert.NotContains(t, tree, "webhooks")
+	assert.NotContains(t, tree, "components")
+	assert.NotContains(t, lookup(t, tree, "paths", "/files", "post"), "callbacks")
+	assert.ElementsMatch(t, []string{
+		"#/webhooks/file.deleted: webhooks need OpenAPI 3.1 and are left out",
+		"#/paths/~1files/post/callbacks/onDone: callbacks need OpenAPI 3 and are left out",
+	}, warnings)
+}
+
+func TestGenerator_UnknownTarget(t *testing.T) {
+	doc, err := parser.New().Parse(targetContent)
+	require.NoError(t, err)
+
+	_, err = New(Config{Target: "4.0"}).Generate(context.Background(), doc, "json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown target "4.0"`)
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"sort"
 	"time"
 
 	"github.com/sukhera/APIWeaver/pkg/errors"
@@ -8,21 +9,37 @@ import (
 
 // Document represents the root of a parsed Markdown API specification
 type Document struct {
-	Frontmatter *Frontmatter         `json:"frontmatter,omitempty"`
-	Endpoints   []*Endpoint          `json:"endpoints"`
-	Components  []*Component         `json:"components,omitempty"`
-	ParsedAt    time.Time            `json:"parsed_at"`
-	Errors      []*errors.ParseError `json:"errors,omitempty"`
+	Frontmatter     *Frontmatter           `json:"frontmatter,omitempty"`
+	Endpoints       []*Endpoint            `json:"endpoints"`
+	Webhooks        []*Webhook             `json:"webhooks,omitempty"`
+	Components      []*Component           `json:"components,omitempty"`
+	Tags            []*Tag                 `json:"tags,omitempty"`
+	SecuritySchemes []*SecurityScheme      `json:"security_schemes,omitempty"`
+	Security        []*SecurityRequirement `json:"security,omitempty"` // default requirements for every endpoint
+	Skipped         []*SkippedEndpoint     `json:"skipped,omitempty"`
+	Extensions      map[string]interface{} `json:"extensions,omitempty"` // key: "x-" vendor extension name
+	ParsedAt        time.Time              `json:"parsed_at"`
+	Errors          []*errors.ParseError   `json:"errors,omitempty"`
+}
+
+// SkippedEndpoint records an endpoint section the parser could not recover
+type SkippedEndpoint struct {
+	Heading    string `json:"heading"`
+	Reason     string `json:"reason"`
+	LineNumber int    `json:"line_number"`
 }
 
 // Frontmatter represents the optional YAML frontmatter at the beginning of the document
 type Frontmatter struct {
-	Title       string            `json:"title,omitempty"`
-	Version     string            `json:"version,omitempty"`
-	Description string            `json:"description,omitempty"`
-	Servers     []Server          `json:"servers,omitempty"`
-	Metadata    map[string]string `json:"metadata,omitempty"`
-	LineNumber  int               `json:"line_number"`
+	Title           string                 `json:"title,omitempty"`
+	Version         string                 `json:"version,omitempty"`
+	Description     string                 `json:"description,omitempty"`
+	Servers         []Server               `json:"servers,omitempty"`
+	Metadata        map[string]string      `json:"metadata,omitempty"`
+	SecuritySchemes []*SecurityScheme      `json:"security_schemes,omitempty"`
+	Security        []*SecurityRequirement `json:"security,omitempty"`
+	Extensions      map[string]interface{} `json:"extensions,omitempty"` // "x-" keys, copied into the document extensions
+	LineNumber      int                    `json:"line_number"`
 }
 
 // Server represents server configuration from frontmatter
@@ -33,44 +50,86 @@ type Server struct {
 
 // Endpoint represents a parsed API endpoint
 type Endpoint struct {
-	Method      string       `json:"method"`
-	Path        string       `json:"path"`
-	Summary     string       `json:"summary,omitempty"`
-	Description string       `json:"description,omitempty"`
-	Parameters  []*Parameter `json:"parameters,omitempty"`
-	RequestBody *RequestBody `json:"request_body,omitempty"`
-	Responses   []*Response  `json:"responses,omitempty"`
-	Tags        []string     `json:"tags,omitempty"`
-	LineNumber  int          `json:"line_number"`
+	Method      string                 `json:"method"`
+	Path        string                 `json:"path"`
+	OperationID string                 `json:"operation_id,omitempty"`
+	Summary     string                 `json:"summary,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  []*Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"request_body,omitempty"`
+	Responses   []*Response            `json:"responses,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Security    []*SecurityRequirement `json:"security,omitempty"` // overrides the document requirements when non-nil, empty for public endpoints
+	Callbacks   []*Callback            `json:"callbacks,omitempty"`
+	Deprecated  *Deprecation           `json:"deprecated,omitempty"`
+	Extensions  map[string]interface{} `json:"extensions,omitempty"` // key: "x-" vendor extension name
+	LineNumber  int                    `json:"line_number"`
+
+	examples []*curlRequest // curl examples, applied once the security schemes are known
+}
+
+// Webhook represents a request the API sends to subscribers, declared with "## WEBHOOK order.created"
+type Webhook struct {
+	Name       string    `json:"name"`
+	Operation  *Endpoint `json:"operation"` // the operation has no path
+	LineNumber int       `json:"line_number"`
+}
+
+// Callback represents a request the API sends back while handling an endpoint, declared under "### Callbacks"
+type Callback struct {
+	Name       string    `json:"name"`
+	Expression string    `json:"expression"` // callback URL, usually a runtime expression such as "{$request.body#/callbackUrl}"
+	Operation  *Endpoint `json:"operation"`  // the operation has no path
+	LineNumber int       `json:"line_number"`
 }
 
 // Parameter represents a request parameter
 type Parameter struct {
-	Name        string      `json:"name"`
-	In          string      `json:"in"` // "query", "path", "header", "cookie"
-	Type        string      `json:"type"`
-	Required    bool        `json:"required"`
-	Description string      `json:"description,omitempty"`
-	Example     interface{} `json:"example,omitempty"`
-	Schema      *Schema     `json:"schema,omitempty"`
-	LineNumber  int         `json:"line_number"`
+	Name        string                 `json:"name"`
+	In          string                 `json:"in"` // "query", "path", "header", "cookie"
+	Type        string                 `json:"type"`
+	Required    bool                   `json:"required"`
+	Description string                 `json:"description,omitempty"`
+	Example     interface{}            `json:"example,omitempty"`
+	Schema      *Schema                `json:"schema,omitempty"`
+	Deprecated  *Deprecation           `json:"deprecated,omitempty"`
+	Extensions  map[string]interface{} `json:"extensions,omitempty"` // key: "x-" vendor extension name
+	Implicit    bool                   `json:"implicit,omitempty"`   // added for a path template variable the markdown does not declare
+	LineNumber  int                    `json:"line_number"`
+}
+
+// Deprecation marks an endpoint, parameter or schema property as deprecated,
+// declared with "**Deprecated:** since 2.3, sunset 2027-01-01, use GET /v2/users"
+type Deprecation struct {
+	Since      string `json:"since,omitempty"`       // version that deprecated it
+	Sunset     string `json:"sunset,omitempty"`      // removal date, YYYY-MM-DD
+	ReplacedBy string `json:"replaced_by,omitempty"` // what to use instead
+	LineNumber int    `json:"line_number"`
 }
 
 // RequestBody represents the request body specification
 type RequestBody struct {
-	Description string             `json:"description,omitempty"`
-	Required    bool               `json:"required"`
-	Content     map[string]*Schema `json:"content"` // key: media type
-	LineNumber  int                `json:"line_number"`
+	Description string                 `json:"description,omitempty"`
+	Required    bool                   `json:"required"`
+	Content     map[string]*Schema     `json:"content"`              // key: media type
+	Encoding    map[string]*Encoding   `json:"encoding,omitempty"`   // key: form field name, applies to multipart content
+	Extensions  map[string]interface{} `json:"extensions,omitempty"` // key: "x-" vendor extension name
+	LineNumber  int                    `json:"line_number"`
+}
+
+// Encoding describes how a multipart form field is serialized
+type Encoding struct {
+	ContentType string `json:"content_type,omitempty"`
 }
 
 // Response represents an API response
 type Response struct {
-	StatusCode  string             `json:"status_code"`
-	Description string             `json:"description,omitempty"`
-	Headers     map[string]*Header `json:"headers,omitempty"`
-	Content     map[string]*Schema `json:"content,omitempty"` // key: media type
-	LineNumber  int                `json:"line_number"`
+	StatusCode  string                 `json:"status_code"`
+	Description string                 `json:"description,omitempty"`
+	Headers     map[string]*Header     `json:"headers,omitempty"`
+	Content     map[string]*Schema     `json:"content,omitempty"`    // key: media type
+	Extensions  map[string]interface{} `json:"extensions,omitempty"` // key: "x-" vendor extension name
+	LineNumber  int                    `json:"line_number"`
 }
 
 // Header represents a response header
@@ -82,19 +141,117 @@ type Header struct {
 
 // Schema represents a JSON/YAML schema definition
 type Schema struct {
-	Type        string             `json:"type,omitempty"`
-	Format      string             `json:"format,omitempty"`
-	Properties  map[string]*Schema `json:"properties,omitempty"`
-	Items       *Schema            `json:"items,omitempty"`
-	Required    []string           `json:"required,omitempty"`
-	Enum        []interface{}      `json:"enum,omitempty"`
-	Example     interface{}        `json:"example,omitempty"`
-	Description string             `json:"description,omitempty"`
-	Ref         string             `json:"$ref,omitempty"`
-	AllOf       []*Schema          `json:"allOf,omitempty"`
-	OneOf       []*Schema          `json:"oneOf,omitempty"`
-	AnyOf       []*Schema          `json:"anyOf,omitempty"`
-	LineNumber  int                `json:"line_number"`
+	Type          string             `json:"type,omitempty"`
+	Format        string             `json:"format,omitempty"`
+	Properties    map[string]*Schema `json:"properties,omitempty"`
+	PropertyOrder []string           `json:"-"` // property names in declaration order
+	Items         *Schema            `json:"items,omitempty"`
+	Required      []string           `json:"required,omitempty"`
+	Enum          []interface{}      `json:"enum,omitempty"`
+	Const         interface{}        `json:"const,omitempty"`
+	Default       interface{}        `json:"default,omitempty"`
+	Example       interface{}        `json:"example,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	Ref           string             `json:"$ref,omitempty"`
+	AllOf         []*Schema          `json:"allOf,omitempty"`
+	OneOf         []*Schema          `json:"oneOf,omitempty"`
+	AnyOf         []*Schema          `json:"anyOf,omitempty"`
+
+	// Validation keywords; nil bounds are unset
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64 `json:"multipleOf,omitempty"`
+	MinLength        *int     `json:"minLength,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	Pattern          string   `json:"pattern,omitempty"`
+	ContentMediaType string   `json:"contentMediaType,omitempty"` // media type of string-encoded content such as file uploads
+	ContentEncoding  string   `json:"contentEncoding,omitempty"`  // e.g. "base64"
+	MinItems         *int     `json:"minItems,omitempty"`
+	MaxItems         *int     `json:"maxItems,omitempty"`
+	UniqueItems      bool     `json:"uniqueItems,omitempty"`
+	MinProperties    *int     `json:"minProperties,omitempty"`
+	MaxProperties    *int     `json:"maxProperties,omitempty"`
+	Nullable         bool     `json:"nullable,omitempty"` // null is allowed in addition to Type
+	ReadOnly         bool     `json:"readOnly,omitempty"`
+	WriteOnly        bool     `json:"writeOnly,omitempty"`
+
+	Deprecated *Deprecation `json:"deprecated,omitempty"`
+
+	Extensions map[string]interface{} `json:"extensions,omitempty"` // key: "x-" vendor extension name
+	Inferred   bool                   `json:"inferred,omitempty"`   // derived from an example payload
+	LineNumber int                    `json:"line_number"`
+}
+
+// SetProperty adds or replaces a property, keeping the declaration position of a replaced one
+func (s *Schema) SetProperty(name string, property *Schema) {
+	if s.Properties == nil {
+		s.Properties = make(map[string]*Schema)
+	}
+	if _, ok := s.Properties[name]; !ok {
+		s.PropertyOrder = append(s.PropertyOrder, name)
+	}
+	s.Properties[name] = property
+}
+
+// PropertyNames returns the property names in declaration order. Properties added without
+// SetProperty follow, sorted by name.
+func (s *Schema) PropertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	seen := make(map[string]bool, len(s.Properties))
+	for _, name := range s.PropertyOrder {
+		if _, ok := s.Properties[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	var rest []string
+	for name := range s.Properties {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// Tag represents an endpoint group, named by a top-level heading or an explicit "**Tags:**" line
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	LineNumber  int    `json:"line_number"`
+}
+
+// SecurityScheme represents an authentication scheme declared for the API
+type SecurityScheme struct {
+	Name             string       `json:"name"`
+	Type             string       `json:"type"`             // "http", "apiKey", "oauth2", "openIdConnect"
+	Scheme           string       `json:"scheme,omitempty"` // HTTP scheme such as "bearer" or "basic"
+	BearerFormat     string       `json:"bearer_format,omitempty"`
+	In               string       `json:"in,omitempty"`             // API key location: "header", "query", "cookie"
+	ParameterName    string       `json:"parameter_name,omitempty"` // API key header, query or cookie name
+	OpenIDConnectURL string       `json:"openid_connect_url,omitempty"`
+	Flows            []*OAuthFlow `json:"flows,omitempty"`
+	Description      string       `json:"description,omitempty"`
+	LineNumber       int          `json:"line_number"`
+}
+
+// OAuthFlow represents an OAuth2 flow of a security scheme
+type OAuthFlow struct {
+	Type             string            `json:"type"` // "authorizationCode", "clientCredentials", "implicit", "password"
+	AuthorizationURL string            `json:"authorization_url,omitempty"`
+	TokenURL         string            `json:"token_url,omitempty"`
+	RefreshURL       string            `json:"refresh_url,omitempty"`
+	Scopes           map[string]string `json:"scopes,omitempty"` // key: scope name
+}
+
+// SecurityRequirement names a security scheme and the scopes required from it
+type SecurityRequirement struct {
+	Scheme     string   `json:"scheme"`
+	Scopes     []string `json:"scopes,omitempty"`
+	LineNumber int      `json:"line_number"`
 }
 
 // Component represents a reusable component definition
@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+// componentSchemaPrefix is the JSON pointer prefix of reusable schemas
+const componentSchemaPrefix = "#/components/schemas/"
+
+var (
+	componentTitlePattern = regexp.MustCompile("^(?:([A-Za-z]+)\\s*:\\s*)?`?([A-Za-z_][\\w-]*)`?$")
+	componentNamePattern  = regexp.MustCompile(`^[A-Za-z_][\w-]*$`)
+	schemaRefPattern      = regexp.MustCompile("(?i)^(?:[-*+]\\s+)?(?:\\*\\*)?(?:schema|\\$ref)\\s*(?:\\*\\*)?\\s*:\\s*(?:\\*\\*)?\\s*`?([A-Za-z_][\\w-]*|#/components/schemas/[A-Za-z_][\\w-]*)(\\[\\])?`?$")
+)
+
+// isComponentsSection reports whether a section title introduces reusable components
+func isComponentsSection(title string) bool {
+	return normalizeTitle(title) == "components"
+}
+
+// parseComponents parses the "## Schema: User" definitions under every "# Components" section
+func (p *Parser) parseComponents(ctx context.Context, root *section) ([]*Component, []*errors.ParseError) {
+	components := []*Component{}
+	var parseErrors []*errors.ParseError
+	seen := make(map[string]*Component)
+
+	var walk func(sec *section)
+	walk = func(sec *section) {
+		for _, child := range sec.children {
+			if ctx.Err() != nil {
+				return
+			}
+			if !isComponentsSection(child.title) {
+				walk(child)
+				continue
+			}
+
+			for _, definition := range child.children {
+				if endpointTitlePattern.MatchString(definition.title) || isWebhookSection(definition) {
+					// Endpoints may follow the components without a new top-level heading
+					continue
+				}
+
+				component, errs := p.parseComponent(definition)
+				parseErrors = append(parseErrors, errs...)
+				if component == nil {
+					continue
+				}
+
+				if existing := seen[component.Name]; existing != nil {
+					parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeReference,
+						fmt.Sprintf("duplicate component '%s', first defined on line %d", component.Name, existing.LineNumber)).
+						AtLine(component.LineNumber).
+						InSource("component").
+						WithSuggestion("Rename or remove one of the definitions").
+						Build())
+					continue
+				}
+				seen[component.Name] = component
+				components = append(components, component)
+			}
+		}
+	}
+	walk(root)
+
+	return components, parseErrors
+}
+
+// parseComponent parses a single "## Schema: User" section
+func (p *Parser) parseComponent(sec *section) (*Component, []*errors.ParseError) {
+	matches := componentTitlePattern.FindStringSubmatch(strings.TrimSpace(sec.title))
+	if matches == nil {
+		return nil, []*errors.ParseError{errors.NewError(errors.ErrorTypeSyntax, "malformed component heading: "+sec.title).
+			AtLine(sec.line).
+			InSource("component").
+			WithSuggestion("Use the form '## Schema: User'").
+			Build()}
+	}
+	if kind := strings.ToLower(matches[1]); kind != "" && kind != "schema" {
+		return nil, []*errors.ParseError{errors.NewError(errors.ErrorTypeSyntax,
+			fmt.Sprintf("unsupported component type %q", matches[1])).
+			AtLine(sec.line).
+			InSource("component").
+			WithSuggestion("Only schema components are supported, use '## Schema: " + matches[2] + "'").
+			Build()}
+	}
+
+	content, parseErrors := p.parseBodyContent(sec, "", "")
+	schema := componentSchema(content)
+	if schema == nil {
+		parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeSchema,
+			fmt.Sprintf("component '%s' does not define a schema", matches[2])).
+			AtLine(sec.line).
+			InSource("component").
+			WithSuggestion("Add a JSON or YAML schema block or a property table").
+			Build())
+		return nil, parseErrors
+	}
+	if schema.Description == "" && len(content.prose) > 0 {
+		schema.Description = strings.Join(content.prose, "\n\n")
+	}
+
+	return &Component{
+		Name:       matches[2],
+		Type:       "schema",
+		Schema:     schema,
+		LineNumber: sec.line,
+	}, parseErrors
+}
+
+// componentSchema picks the schema of a component body, preferring the default media type
+func componentSchema(content *bodyContent) *Schema {
+	if schema := content.content[defaultMediaType]; schema != nil {
+		return schema
+	}
+
+	mediaTypes := make([]string, 0, len(content.content))
+	for mediaType := range content.content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	if len(mediaTypes) == 0 {
+		return nil
+	}
+	sort.Strings(mediaTypes)
+	return content.content[mediaTypes[0]]
+}
+
+// schemaReference builds the schema for a "Schema: User" or "Schema: User[]" line
+func schemaReference(matches []string, lineNumber int) *Schema {
+	schema := &Schema{Ref: matches[1], LineNumber: lineNumber}
+	if matches[2] != "" {
+		return &Schema{Type: "array", Items: schema, LineNumber: lineNumber}
+	}
+	return schema
+}
+
+// referenceResolver rewrites component references to "#/components/schemas/Name" pointers
+type referenceResolver struct {
+	BaseVisitor
+	names  map[string]bool     // declared component names
+	folded map[string][]string // key: lowercase name
+	errors []*errors.ParseError
+}
+
+// resolveReferences resolves every schema reference in a document against its components
+func resolveReferences(ctx context.Context, doc *Document) []*errors.ParseError {
+	resolver := &referenceResolver{
+		names:  make(map[string]bool, len(doc.Components)),
+		folded: make(map[string][]string, len(doc.Components)),
+	}
+	for _, component := range doc.Components {
+		resolver.names[component.Name] = true
+		key := strings.ToLower(component.Name)
+		resolver.folded[key] = append(resolver.folded[key], component.Name)
+	}
+
+	if err := doc.Accept(ctx, resolver); err != nil && ctx.Err() == nil {
+		resolver.errors = append(resolver.errors, errors.NewError(errors.ErrorTypeReference, err.Error()).Build())
+	}
+	return resolver.errors
+}
+
+// VisitSchema resolves a bare component name or a local "#/components/schemas/Name" pointer.
+// External references are left untouched.
+func (r *referenceResolver) VisitSchema(ctx context.Context, schema *Schema) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	name, fragment := schema.Ref, ""
+	switch {
+	case strings.HasPrefix(name, componentSchemaPrefix):
+		var rest string
+		var nested bool
+		name, rest, nested = strings.Cut(strings.TrimPrefix(name, componentSchemaPrefix), "/")
+		if nested {
+			fragment = "/" + rest
+		}
+	case !componentNamePattern.MatchString(name):
+		return nil
+	}
+
+	if r.names[name] {
+		schema.Ref = componentSchemaPrefix + name + fragment
+		return nil
+	}
+
+	switch candidates := r.folded[strings.ToLower(name)]; len(candidates) {
+	case 1:
+		schema.Ref = componentSchemaPrefix + candidates[0] + fragment
+	case 0:
+		r.errors = append(r.errors, errors.NewError(errors.ErrorTypeReference,
+			fmt.Sprintf("unresolved schema reference '%s'", schema.Ref)).
+			AtLine(schema.LineNumber).
+			InSource("schema").
+			WithSuggestion("Define it under '# Components' as '## Schema: "+name+"'").
+			Build())
+	default:
+		r.errors = append(r.errors, errors.NewError(errors.ErrorTypeReference,
+			fmt.Sprintf("ambiguous schema reference '%s' matches %s", schema.Ref, strings.Join(candidates, ", "))).
+			AtLine(schema.LineNumber).
+			InSource("schema").
+			WithSuggestion("Use the exact component name").
+			Build())
+	}
+	return nil
+}
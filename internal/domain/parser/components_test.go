@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+const fixtureComponents = "# Components\n\n" +
+	"## Schema: User\n\n" +
+	"A registered user.\n\n" +
+	"| Field | Type | Required |\n" +
+	"|-------|------|----------|\n" +
+	"| id | integer | yes |\n" +
+	"| name | string | yes |\n\n" +
+	"## Schema: Team\n\n" +
+	"```yaml\n" +
+	"type: object\n" +
+	"properties:\n" +
+	"  members:\n" +
+	"    type: array\n" +
+	"    items:\n" +
+	"      $ref: User\n" +
+	"```\n\n"
+
+func TestParser_ParseComponents(t *testing.T) {
+	content := fixtureComponents +
+		"# Users\n\n" +
+		"## GET /users/{id}\n\n" +
+		"### Response 200\n\n" +
+		"**Schema:** User\n\n" +
+		"## GET /users\n\n" +
+		"### Response 200\n\n" +
+		"- Schema: `user[]`\n\n" +
+		"## POST /teams\n\n" +
+		"### Request Body\n\n" +
+		"```json\n{\"$ref\": \"#/components/schemas/Team\"}\n```\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	assert.Empty(t, doc.Errors)
+	require.Len(t, doc.Endpoints, 3)
+
+	require.Len(t, doc.Components, 2)
+	user := doc.Components[0]
+	assert.Equal(t, "User", user.Name)
+	assert.Equal(t, "schema", user.Type)
+	assert.Equal(t, 3, user.LineNumber)
+	require.NotNil(t, user.Schema)
+	assert.Equal(t, "object", user.Schema.Type)
+	assert.Equal(t, "A registered user.", user.Schema.Description)
+	assert.Equal(t, []string{"id", "name"}, user.Schema.Required)
+
+	team := doc.Components[1]
+	assert.Equal(t, "Team", team.Name)
+	assert.Equal(t, "#/components/schemas/User", team.Schema.Properties["members"].Items.Ref)
+
+	single := doc.Endpoints[0].Responses[0].Content["application/json"]
+	require.NotNil(t, single)
+	assert.Equal(t, "#/components/schemas/User", single.Ref)
+	assert.Equal(t, 29, single.LineNumber)
+
+	list := doc.Endpoints[1].Responses[0].Content["application/json"]
+	require.NotNil(t, list)
+	assert.Equal(t, "array", list.Type)
+	assert.Equal(t, "#/components/schemas/User", list.Items.Ref)
+
+	body := doc.Endpoints[2].RequestBody.Content["application/json"]
+	assert.Equal(t, "#/components/schemas/Team", body.Ref)
+}
+
+func TestParser_ParseComponentErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		message string
+		lineNum int
+		errType errors.ErrorType
+	}{
+		{
+			name:    "unresolved reference",
+			content: fixtureComponents + "## GET /orders\n\n### Response 200\n\nSchema: Order\n",
+			message: "unresolved schema reference 'Order'",
+			lineNum: 27,
+			errType: errors.ErrorTypeReference,
+		},
+		{
+			name:    "unresolved pointer reference",
+			content: fixtureComponents + "## GET /orders\n\n### Response 200\n\nSchema: `#/components/schemas/Missing`\n",
+			message: "unresolved schema reference '#/components/schemas/Missing'",
+			lineNum: 27,
+			errType: errors.ErrorTypeReference,
+		},
+		{
+			name: "ambiguous reference",
+			content: "# Components\n\n## Schema: Item\n\n```json\n{\"type\": \"object\"}\n```\n\n" +
+				"## Schema: ITEM\n\n```json\n{\"type\": \"string\"}\n```\n\n" +
+				"## GET /items\n\n### Response 200\n\nSchema: item\n",
+			message: "ambiguous schema reference 'item' matches Item, ITEM",
+			lineNum: 19,
+			errType: errors.ErrorTypeReference,
+		},
+		{
+			name:    "duplicate component",
+			content: fixtureComponents + "## Schema: User\n\n```json\n{\"type\": \"object\"}\n```\n",
+			message: "duplicate component 'User', first defined on line 3",
+			lineNum: 23,
+			errType: errors.ErrorTypeReference,
+		},
+		{
+			name:    "component without schema",
+			content: "# Components\n\n## Schema: Empty\n\nNothing here.\n",
+			message: "component 'Empty' does not define a schema",
+			lineNum: 3,
+			errType: errors.ErrorTypeSchema,
+		},
+		{
+			name:    "unsupported component type",
+			content: "# Components\n\n## Parameter: Limit\n\n```json\n{\"type\": \"integer\"}\n```\n",
+			message: "unsupported component type \"Parameter\"",
+			lineNum: 3,
+			errType: errors.ErrorTypeSyntax,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New().Parse(tt.content)
+			require.NoError(t, err)
+			require.Len(t, doc.Errors, 1)
+			assert.Equal(t, tt.errType, doc.Errors[0].Type)
+			assert.Equal(t, tt.message, doc.Errors[0].Message)
+			assert.Equal(t, tt.lineNum, doc.Errors[0].LineNumber)
+			assert.NotEmpty(t, doc.Errors[0].Suggestion)
+		})
+	}
+}
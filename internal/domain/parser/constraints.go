@@ -0,0 +1,262 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// boundKind identifies which keyword pair a shorthand bound such as "max 255" sets
+type boundKind int
+
+const (
+	boundValue boundKind = iota
+	boundLength
+	boundItems
+	boundProperties
+)
+
+const numberExpr = `(-?\d+(?:\.\d+)?)`
+
+var (
+	rangePattern      = regexp.MustCompile(`^` + numberExpr + `?\s*\.\.\s*` + numberExpr + `?$`)
+	betweenPattern    = regexp.MustCompile(`(?i)^between\s+` + numberExpr + `\s+and\s+` + numberExpr + `$`)
+	comparisonPattern = regexp.MustCompile(`^(>=|<=|>|<|≥|≤)\s*` + numberExpr + `$`)
+	boundPattern      = regexp.MustCompile(`(?i)^(min|max)(?:imum)?[ _-]?(length|len|items|properties)?\s*:?\s*` + numberExpr + `\s*(chars?|characters|items|properties)?$`)
+	multipleOfPattern = regexp.MustCompile(`(?i)^multiple[ _-]?of\s*:?\s*` + numberExpr + `$`)
+	patternPattern    = regexp.MustCompile(`(?i)^pattern\s*:?\s*(.+)$`)
+	defaultPattern    = regexp.MustCompile(`(?i)^defaults?(?:\s+to)?\s*:?\s+(.+)$`)
+)
+
+// applyConstraint applies a shorthand constraint such as "1..100", "max 255 chars", "default 20"
+// or "read-only" to a schema. It reports false when the text is not a constraint.
+func applyConstraint(schema *Schema, text string) bool {
+	text = strings.TrimSpace(text)
+
+	switch strings.ToLower(text) {
+	case "nullable":
+		schema.Nullable = true
+		return true
+	case "read-only", "readonly", "read only":
+		schema.ReadOnly = true
+		return true
+	case "write-only", "writeonly", "write only":
+		schema.WriteOnly = true
+		return true
+	case "unique", "unique items", "uniqueitems":
+		schema.UniqueItems = true
+		return true
+	}
+
+	if matches := rangePattern.FindStringSubmatch(text); matches != nil && (matches[1] != "" || matches[2] != "") {
+		return applyRange(schema, matches[1], matches[2])
+	}
+	if matches := betweenPattern.FindStringSubmatch(text); matches != nil {
+		return applyRange(schema, matches[1], matches[2])
+	}
+	if matches := comparisonPattern.FindStringSubmatch(text); matches != nil {
+		value, _ := strconv.ParseFloat(matches[2], 64)
+		switch matches[1] {
+		case ">=", "≥":
+			return applyBound(schema, defaultBoundKind(schema), true, value, false)
+		case "<=", "≤":
+			return applyBound(schema, defaultBoundKind(schema), false, value, false)
+		case ">":
+			return applyBound(schema, defaultBoundKind(schema), true, value, true)
+		default:
+			return applyBound(schema, defaultBoundKind(schema), false, value, true)
+		}
+	}
+	if matches := boundPattern.FindStringSubmatch(text); matches != nil {
+		value, _ := strconv.ParseFloat(matches[3], 64)
+		kind := defaultBoundKind(schema)
+		switch unit := strings.ToLower(matches[2] + matches[4]); {
+		case strings.HasPrefix(unit, "len"), strings.HasPrefix(unit, "char"):
+			kind = boundLength
+		case strings.HasPrefix(unit, "item"):
+			kind = boundItems
+		case strings.HasPrefix(unit, "propert"):
+			kind = boundProperties
+		}
+		return applyBound(schema, kind, strings.EqualFold(matches[1], "min"), value, false)
+	}
+	if matches := multipleOfPattern.FindStringSubmatch(text); matches != nil {
+		value, _ := strconv.ParseFloat(matches[1], 64)
+		if value <= 0 {
+			return false
+		}
+		schema.MultipleOf = &value
+		return true
+	}
+	if matches := patternPattern.FindStringSubmatch(text); matches != nil {
+		schema.Pattern = strings.Trim(strings.TrimSpace(matches[1]), "`")
+		return true
+	}
+	if matches := defaultPattern.FindStringSubmatch(text); matches != nil {
+		schema.Default = parseScalarValue(matches[1], schema.Type)
+		return true
+	}
+
+	return false
+}
+
+// applyRange applies an inclusive "min..max" range; either side may be empty
+func applyRange(schema *Schema, minText, maxText string) bool {
+	kind := defaultBoundKind(schema)
+	if minText != "" {
+		value, _ := strconv.ParseFloat(minText, 64)
+		if !applyBound(schema, kind, true, value, false) {
+			return false
+		}
+	}
+	if maxText != "" {
+		value, _ := strconv.ParseFloat(maxText, 64)
+		if !applyBound(schema, kind, false, value, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultBoundKind returns what an unqualified bound limits for the schema type
+func defaultBoundKind(schema *Schema) boundKind {
+	switch schema.Type {
+	case "string":
+		return boundLength
+	case "array":
+		return boundItems
+	case "object":
+		return boundProperties
+	default:
+		return boundValue
+	}
+}
+
+// applyBound sets a lower or upper bound. Length, item and property counts must be
+// non-negative integers; exclusive count bounds are converted to inclusive ones.
+func applyBound(schema *Schema, kind boundKind, lower bool, value float64, exclusive bool) bool {
+	if kind == boundValue {
+		switch {
+		case lower && exclusive:
+			schema.ExclusiveMinimum = &value
+		case lower:
+			schema.Minimum = &value
+		case exclusive:
+			schema.ExclusiveMaximum = &value
+		default:
+			schema.Maximum = &value
+		}
+		return true
+	}
+
+	if value != math.Trunc(value) {
+		return false
+	}
+	count := int(value)
+	if exclusive && lower {
+		count++
+	} else if exclusive {
+		count--
+	}
+	if count < 0 {
+		return false
+	}
+
+	switch {
+	case kind == boundLength && lower:
+		schema.MinLength = &count
+	case kind == boundLength:
+		schema.MaxLength = &count
+	case kind == boundItems && lower:
+		schema.MinItems = &count
+	case kind == boundItems:
+		schema.MaxItems = &count
+	case lower:
+		schema.MinProperties = &count
+	default:
+		schema.MaxProperties = &count
+	}
+	return true
+}
+
+// applyConstraintCell applies the comma separated constraints of a table cell, warning about unknown ones
+func applyConstraintCell(schema *Schema, row tableRow, columns map[tableColumn]int) []*errors.ParseError {
+	var parseErrors []*errors.ParseError
+	for _, constraint := range splitAttributes(cellValue(row, columns, columnConstraints)) {
+		if applyConstraint(schema, constraint) {
+			continue
+		}
+		parseErrors = append(parseErrors, errors.NewWarning(errors.ErrorTypeTable,
+			fmt.Sprintf("unknown constraint %q is ignored", constraint)).
+			AtPosition(row.line, row.columns[columns[columnConstraints]]).
+			InSource("table").
+			WithSuggestion("Use constraints such as '1..100', 'max 255 chars', 'pattern ^[a-z]+$' or 'read-only'").
+			Build())
+	}
+	if value := cellValue(row, columns, columnDefault); value != "" {
+		schema.Default = parseScalarValue(value, schema.Type)
+	}
+	return parseErrors
+}
+
+// number reads a numeric schema keyword
+func (b *schemaBuilder) number(key string, node *yaml.Node) *float64 {
+	var value float64
+	if node.Kind != yaml.ScalarNode || node.Decode(&value) != nil {
+		b.errors = append(b.errors, errors.NewSchemaError(fmt.Sprintf("'%s' must be a number", key), b.lineOf(node)))
+		return nil
+	}
+	return &value
+}
+
+// count reads a non-negative integer schema keyword such as maxLength
+func (b *schemaBuilder) count(key string, node *yaml.Node) *int {
+	var value int
+	if node.Kind != yaml.ScalarNode || node.Decode(&value) != nil || value < 0 {
+		b.errors = append(b.errors, errors.NewSchemaError(fmt.Sprintf("'%s' must be a non-negative integer", key), b.lineOf(node)))
+		return nil
+	}
+	return &value
+}
+
+// flag reads a boolean schema keyword
+func (b *schemaBuilder) flag(key string, node *yaml.Node) bool {
+	var value bool
+	if node.Kind != yaml.ScalarNode || node.Decode(&value) != nil {
+		b.errors = append(b.errors, errors.NewSchemaError(fmt.Sprintf("'%s' must be true or false", key), b.lineOf(node)))
+		return false
+	}
+	return value
+}
+
+// exclusiveBound reads exclusiveMinimum/exclusiveMaximum. JSON Schema uses a number, while
+// OpenAPI 3.0 uses a boolean that makes the matching inclusive bound exclusive.
+func (b *schemaBuilder) exclusiveBound(key string, node *yaml.Node, inclusive **float64) *float64 {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!bool" {
+		if !b.flag(key, node) || *inclusive == nil {
+			return nil
+		}
+		bound := *inclusive
+		*inclusive = nil
+		return bound
+	}
+	return b.number(key, node)
+}
+
+// allowsNull reports whether a type keyword lists "null" next to another type
+func allowsNull(node *yaml.Node) bool {
+	if node.Kind != yaml.SequenceNode || len(node.Content) < 2 {
+		return false
+	}
+	for _, item := range node.Content {
+		if item.Value == "null" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+func floatPtr(value float64) *float64 { return &value }
+
+func intPtr(value int) *int { return &value }
+
+func TestApplyConstraint(t *testing.T) {
+	tests := []struct {
+		name       string
+		schemaType string
+		constraint string
+		expected   *Schema
+		ok         bool
+	}{
+		{name: "numeric range", schemaType: "integer", constraint: "1..100",
+			expected: &Schema{Type: "integer", Minimum: floatPtr(1), Maximum: floatPtr(100)}, ok: true},
+		{name: "open range", schemaType: "number", constraint: "0.5..",
+			expected: &Schema{Type: "number", Minimum: floatPtr(0.5)}, ok: true},
+		{name: "string range sets length", schemaType: "string", constraint: "between 3 and 64",
+			expected: &Schema{Type: "string", MinLength: intPtr(3), MaxLength: intPtr(64)}, ok: true},
+		{name: "max chars", schemaType: "string", constraint: "max 255 chars",
+			expected: &Schema{Type: "string", MaxLength: intPtr(255)}, ok: true},
+		{name: "explicit length on untyped schema", schemaType: "", constraint: "minLength: 2",
+			expected: &Schema{MinLength: intPtr(2)}, ok: true},
+		{name: "exclusive comparison", schemaType: "number", constraint: "> 0",
+			expected: &Schema{Type: "number", ExclusiveMinimum: floatPtr(0)}, ok: true},
+		{name: "exclusive count comparison", schemaType: "array", constraint: "< 10",
+			expected: &Schema{Type: "array", MaxItems: intPtr(9)}, ok: true},
+		{name: "multiple of", schemaType: "integer", constraint: "multiple of 5",
+			expected: &Schema{Type: "integer", MultipleOf: floatPtr(5)}, ok: true},
+		{name: "pattern", schemaType: "string", constraint: "pattern `^[a-z]{1,3}$`",
+			expected: &Schema{Type: "string", Pattern: "^[a-z]{1,3}$"}, ok: true},
+		{name: "default", schemaType: "integer", constraint: "defaults to 20",
+			expected: &Schema{Type: "integer", Default: 20}, ok: true},
+		{name: "flags", schemaType: "string", constraint: "read-only",
+			expected: &Schema{Type: "string", ReadOnly: true}, ok: true},
+		{name: "fractional length", schemaType: "string", constraint: "max 2.5",
+			expected: &Schema{Type: "string"}, ok: false},
+		{name: "not a constraint", schemaType: "string", constraint: "deprecated",
+			expected: &Schema{Type: "string"}, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &Schema{Type: tt.schemaType}
+			assert.Equal(t, tt.ok, applyConstraint(schema, tt.constraint))
+			assert.Equal(t, tt.expected, schema)
+		})
+	}
+}
+
+func TestParser_ParseSchemaConstraints(t *testing.T) {
+	content := "## GET /users\n\n" +
+		"### Query Parameters\n\n" +
+		"- **limit** (query, integer, 1..100, default 20) - Page size\n" +
+		"- **q** (query, string, max 255 chars, pattern `^[a-z, ]+$`) - Search text\n\n" +
+		"| Name | Type | Constraints | Default |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| sort | string | min 1, bogus | name |\n\n" +
+		"### Response 200\n\n" +
+		"```yaml\n" +
+		"type: object\n" +
+		"properties:\n" +
+		"  id:\n" +
+		"    type: [integer, \"null\"]\n" +
+		"    minimum: 0\n" +
+		"    exclusiveMinimum: true\n" +
+		"    readOnly: true\n" +
+		"  tags:\n" +
+		"    type: array\n" +
+		"    items: {type: string}\n" +
+		"    minItems: 1\n" +
+		"    uniqueItems: true\n" +
+		"    default: []\n" +
+		"```\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Errors, 1)
+	assert.Equal(t, errors.ErrorTypeTable, doc.Errors[0].Type)
+	assert.Equal(t, errors.SeverityWarning, doc.Errors[0].Severity)
+	assert.Equal(t, "unknown constraint \"bogus\" is ignored", doc.Errors[0].Message)
+
+	require.Len(t, doc.Endpoints, 1)
+	parameters := doc.Endpoints[0].Parameters
+	require.Len(t, parameters, 3)
+	assert.Equal(t, &Schema{Type: "integer", Minimum: floatPtr(1), Maximum: floatPtr(100), Default: 20, LineNumber: 5},
+		parameters[0].Schema)
+	assert.Equal(t, &Schema{Type: "string", MaxLength: intPtr(255), Pattern: "^[a-z, ]+$", LineNumber: 6},
+		parameters[1].Schema)
+	assert.Equal(t, &Schema{Type: "string", MinLength: intPtr(1), Default: "name", LineNumber: 10},
+		parameters[2].Schema)
+
+	schema := doc.Endpoints[0].Responses[0].Content[defaultMediaType]
+	id := schema.Properties["id"]
+	assert.Equal(t, "integer", id.Type)
+	assert.True(t, id.Nullable)
+	assert.True(t, id.ReadOnly)
+	assert.Nil(t, id.Minimum)
+	assert.Equal(t, floatPtr(0), id.ExclusiveMinimum)
+
+	tags := schema.Properties["tags"]
+	assert.Equal(t, intPtr(1), tags.MinItems)
+	assert.True(t, tags.UniqueItems)
+	assert.Equal(t, []interface{}{}, tags.Default)
+}
+
+func TestValidationVisitor_SchemaConstraints(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  *Schema
+		message string
+	}{
+		{
+			name:    "minimum above maximum",
+			schema:  &Schema{Type: "integer", Minimum: floatPtr(10), Maximum: floatPtr(1)},
+			message: "schema minimum is greater than its maximum",
+		},
+		{
+			name:    "minLength above maxLength",
+			schema:  &Schema{Type: "string", MinLength: intPtr(5), MaxLength: intPtr(2)},
+			message: "schema minLength is greater than its maxLength",
+		},
+		{
+			name:    "read and write only",
+			schema:  &Schema{Type: "string", ReadOnly: true, WriteOnly: true},
+			message: "schema cannot be both readOnly and writeOnly",
+		},
+		{
+			name:    "invalid pattern",
+			schema:  &Schema{Type: "string", Pattern: "[a-"},
+			message: "schema pattern is not a valid regular expression: error parsing regexp: missing closing ]: `[a-`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visitor := NewValidationVisitor(false)
+			require.NoError(t, tt.schema.Accept(context.Background(), visitor))
+			require.Len(t, visitor.GetErrors(), 1)
+			assert.Equal(t, tt.message, visitor.GetErrors()[0].Message)
+		})
+	}
+}
@@ -0,0 +1,640 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// curlValueFlags lists the curl options that take a value but carry nothing the parser uses
+var curlValueFlags = map[string]bool{
+	"-u": true, "--user": true, "-o": true, "--output": true, "-A": true, "--user-agent": true,
+	"-e": true, "--referer": true, "-m": true, "--max-time": true, "--connect-timeout": true,
+	"--retry": true, "-w": true, "--write-out": true, "-x": true, "--proxy": true, "-b": true,
+	"--cookie": true, "-c": true, "--cookie-jar": true, "--cacert": true, "--cert": true,
+	"--key": true, "--resolve": true, "-T": true, "--upload-file": true, "-K": true, "--config": true,
+}
+
+// ignoredHeaderParameters lists headers OpenAPI describes elsewhere than in header parameters
+var ignoredHeaderParameters = map[string]bool{"accept": true, "content-type": true, "authorization": true}
+
+// curlRequest is a request described by a curl command in an example block
+type curlRequest struct {
+	method       string // explicit -X method, empty when implied
+	url          string
+	headers      [][2]string // name and value, in command order
+	data         []string
+	urlencoded   bool // data was passed with --data-urlencode
+	form         [][2]string
+	queryFromGet bool // -G moves the data into the query string
+	line         int
+}
+
+// isExampleSection reports whether an endpoint subsection holds request examples
+func isExampleSection(title string) bool {
+	switch normalizeTitle(title) {
+	case "example", "examples", "example request", "request example", "curl example":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseExampleSection parses the curl commands of the shell blocks in an example section
+func parseExampleSection(sec *section) ([]*curlRequest, []*errors.ParseError) {
+	var requests []*curlRequest
+	var parseErrors []*errors.ParseError
+
+	blocks, _ := scanBlocks(sec.body, "")
+	for _, child := range sec.children {
+		childBlocks, _ := scanBlocks(child.body, "")
+		blocks = append(blocks, childBlocks...)
+	}
+
+	for _, block := range blocks {
+		if block.kind != blockFence || !isShellFence(block.info) {
+			continue
+		}
+
+		// Commands are reported at the line they start on
+		texts := make([]string, len(block.lines))
+		var commandLines []int
+		for i, l := range block.lines {
+			texts[i] = l.text
+			if strings.HasPrefix(strings.TrimPrefix(strings.TrimSpace(l.text), "$ "), "curl ") {
+				commandLines = append(commandLines, l.number)
+			}
+		}
+		words, err := splitShellWords(strings.Join(texts, "\n"))
+		if err != nil {
+			parseErrors = append(parseErrors, errors.NewWarning(errors.ErrorTypeSyntax,
+				"curl example cannot be read: "+err.Error()).
+				AtLine(block.line).
+				InSource("example").
+				Build())
+			continue
+		}
+
+		for _, command := range splitShellCommands(words) {
+			if len(command) == 0 || command[0] != "curl" {
+				continue
+			}
+			lineNumber := block.line + 1
+			if len(commandLines) > 0 {
+				lineNumber, commandLines = commandLines[0], commandLines[1:]
+			}
+			request, err := parseCurlCommand(command[1:], lineNumber)
+			if err != nil {
+				parseErrors = append(parseErrors, err)
+				continue
+			}
+			requests = append(requests, request)
+		}
+	}
+
+	return requests, parseErrors
+}
+
+// isShellFence reports whether a fence info string marks a shell snippet
+func isShellFence(info string) bool {
+	fields := strings.Fields(info)
+	if len(fields) == 0 {
+		return true
+	}
+	switch fields[0] {
+	case "bash", "sh", "shell", "console", "zsh", "curl":
+		return true
+	default:
+		return false
+	}
+}
+
+// splitShellWords splits a shell snippet into words, honouring quotes, backslash escapes and
+// line continuations. Command separators are returned as separate words.
+func splitShellWords(text string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	quote := byte(0)
+
+	flush := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				word.WriteByte(c)
+			}
+		case quote == '"':
+			switch {
+			case c == '"':
+				quote = 0
+			case c == '\\' && i+1 < len(text) && strings.IndexByte("\"\\$`\n", text[i+1]) >= 0:
+				i++
+				if text[i] != '\n' {
+					word.WriteByte(text[i])
+				}
+			default:
+				word.WriteByte(c)
+			}
+		case c == '\\':
+			if i+1 < len(text) {
+				i++
+				if text[i] != '\n' {
+					word.WriteByte(text[i])
+					inWord = true
+				}
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == '$' && !inWord && i+1 < len(text) && text[i+1] == ' ' && (i == 0 || text[i-1] == '\n'):
+			// Prompt marker of a console snippet
+			i++
+		case c == '#' && !inWord:
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+			flush()
+			words = append(words, "\n")
+		case c == '\n' || c == ';' || c == '|' || c == '&':
+			flush()
+			words = append(words, string(c))
+		case c == ' ' || c == '\t' || c == '\r':
+			flush()
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	flush()
+	return words, nil
+}
+
+// splitShellCommands splits shell words into commands at newlines and command separators
+func splitShellCommands(words []string) [][]string {
+	var commands [][]string
+	var current []string
+	for _, word := range words {
+		switch word {
+		case "\n", ";", "|", "&":
+			if len(current) > 0 {
+				commands = append(commands, current)
+			}
+			current = nil
+		default:
+			current = append(current, word)
+		}
+	}
+	if len(current) > 0 {
+		commands = append(commands, current)
+	}
+	return commands
+}
+
+// parseCurlCommand reads the method, URL, headers and body of curl arguments
+func parseCurlCommand(args []string, lineNumber int) (*curlRequest, *errors.ParseError) {
+	request := &curlRequest{line: lineNumber}
+
+	for i := 0; i < len(args); i++ {
+		flag, value, hasValue := args[i], "", false
+		if strings.HasPrefix(flag, "--") {
+			if name, attached, ok := strings.Cut(flag, "="); ok {
+				flag, value, hasValue = name, attached, true
+			}
+		} else if len(flag) > 2 && flag[0] == '-' && strings.IndexByte("XHdF", flag[1]) >= 0 {
+			flag, value, hasValue = flag[:2], flag[2:], true
+		}
+
+		takeValue := func() bool {
+			if hasValue {
+				return true
+			}
+			if i+1 >= len(args) {
+				return false
+			}
+			i++
+			value = args[i]
+			return true
+		}
+
+		switch flag {
+		case "-X", "--request":
+			if takeValue() {
+				request.method = strings.ToUpper(value)
+			}
+		case "-H", "--header":
+			if takeValue() {
+				if name, headerValue, ok := strings.Cut(value, ":"); ok {
+					request.headers = append(request.headers, [2]string{strings.TrimSpace(name), strings.TrimSpace(headerValue)})
+				}
+			}
+		case "-d", "--data", "--data-raw", "--data-binary", "--data-ascii", "--json":
+			if takeValue() {
+				request.data = append(request.data, value)
+			}
+			if flag == "--json" {
+				request.headers = append(request.headers, [2]string{"Content-Type", defaultMediaType})
+			}
+		case "--data-urlencode":
+			if takeValue() {
+				request.data = append(request.data, value)
+				request.urlencoded = true
+			}
+		case "-F", "--form", "--form-string":
+			if takeValue() {
+				if name, fieldValue, ok := strings.Cut(value, "="); ok {
+					request.form = append(request.form, [2]string{name, fieldValue})
+				}
+			}
+		case "-G", "--get":
+			request.queryFromGet = true
+		case "--url":
+			if takeValue() {
+				request.url = value
+			}
+		default:
+			switch {
+			case curlValueFlags[flag]:
+				takeValue()
+			case strings.HasPrefix(flag, "-"):
+				// Flags without a value such as -s, -i or --compressed
+			case request.url == "":
+				request.url = flag
+			}
+		}
+	}
+
+	if request.url == "" {
+		return nil, errors.NewWarning(errors.ErrorTypeSyntax, "curl example has no URL").
+			AtLine(lineNumber).
+			InSource("example").
+			Build()
+	}
+	return request, nil
+}
+
+// effectiveMethod returns the method curl sends, implied by the body when -X is absent
+func (r *curlRequest) effectiveMethod() string {
+	switch {
+	case r.method != "":
+		return r.method
+	case r.queryFromGet:
+		return "GET"
+	case len(r.data) > 0 || len(r.form) > 0:
+		return "POST"
+	default:
+		return "GET"
+	}
+}
+
+// header returns the value of the last header with the given name
+func (r *curlRequest) header(name string) string {
+	value := ""
+	for _, header := range r.headers {
+		if strings.EqualFold(header[0], name) {
+			value = header[1]
+		}
+	}
+	return value
+}
+
+// mediaType returns the request content type, from the Content-Type header or implied by the body flags
+func (r *curlRequest) mediaType() string {
+	if contentType := r.header("Content-Type"); contentType != "" {
+		mediaType, _, _ := strings.Cut(contentType, ";")
+		return strings.ToLower(strings.TrimSpace(mediaType))
+	}
+	switch {
+	case len(r.form) > 0:
+		return multipartMediaType
+	case len(r.data) == 0:
+		return ""
+	case !r.urlencoded && json.Valid([]byte(strings.Join(r.data, "&"))):
+		// curl itself sends urlencoded data, but JSON payloads are almost always meant as JSON
+		return defaultMediaType
+	default:
+		return urlencodedMediaType
+	}
+}
+
+// applyCurlExamples fills endpoints from the curl commands of their example sections.
+// Examples add undeclared query and header parameters, parameter examples, an example request
+// body and the request content type. Examples that contradict the declared endpoint are reported.
+// API key parameters of the security schemes are left to the schemes.
+func applyCurlExamples(doc *Document) []*errors.ParseError {
+	credentials := make(map[string]bool)
+	for _, scheme := range doc.SecuritySchemes {
+		if scheme.Type == "apiKey" && scheme.ParameterName != "" {
+			credentials[scheme.In+":"+strings.ToLower(scheme.ParameterName)] = true
+		}
+	}
+
+	var parseErrors []*errors.ParseError
+	for _, endpoint := range doc.Endpoints {
+		for _, request := range endpoint.examples {
+			parseErrors = append(parseErrors, applyCurlExample(endpoint, request, credentials)...)
+		}
+		endpoint.examples = nil
+	}
+	return parseErrors
+}
+
+// applyCurlExample applies a single curl example to an endpoint
+func applyCurlExample(endpoint *Endpoint, request *curlRequest, credentials map[string]bool) []*errors.ParseError {
+	var parseErrors []*errors.ParseError
+	contradiction := func(message string) {
+		parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeValidation, message).
+			AtLine(request.line).
+			InSource("example").
+			WithSuggestion("Update the example or the endpoint definition so they agree").
+			Build())
+	}
+
+	if method := request.effectiveMethod(); method != endpoint.Method {
+		contradiction(fmt.Sprintf("curl example sends %s but the endpoint is %s %s", method, endpoint.Method, endpoint.Path))
+	}
+
+	target, err := url.Parse(request.url)
+	if err != nil {
+		contradiction(fmt.Sprintf("curl example URL %q is invalid: %v", request.url, err))
+		return parseErrors
+	}
+	if !matchesPathTemplate(endpoint.Path, target.Path) {
+		contradiction(fmt.Sprintf("curl example path %s does not match %s", target.Path, endpoint.Path))
+	}
+
+	query := target.Query()
+	if request.queryFromGet {
+		for _, data := range request.data {
+			values, _ := url.ParseQuery(data)
+			for name, value := range values {
+				query[name] = append(query[name], value...)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if credentials["query:"+strings.ToLower(name)] {
+			continue
+		}
+		if message := applyExampleParameter(endpoint, "query", name, query.Get(name), request.line); message != "" {
+			contradiction(message)
+		}
+	}
+
+	for _, header := range request.headers {
+		name := header[0]
+		if ignoredHeaderParameters[strings.ToLower(name)] || credentials["header:"+strings.ToLower(name)] {
+			continue
+		}
+		if message := applyExampleParameter(endpoint, "header", name, header[1], request.line); message != "" {
+			contradiction(message)
+		}
+	}
+
+	if request.queryFromGet {
+		return parseErrors
+	}
+	mediaType := request.mediaType()
+	if mediaType == "" {
+		return parseErrors
+	}
+
+	schema, errs := request.bodySchema(mediaType)
+	parseErrors = append(parseErrors, errs...)
+	if schema == nil {
+		return parseErrors
+	}
+
+	if endpoint.RequestBody == nil {
+		endpoint.RequestBody = &RequestBody{
+			Required:   true,
+			Content:    map[string]*Schema{mediaType: schema},
+			LineNumber: request.line,
+		}
+		return append(parseErrors, completeRequestBody(endpoint.RequestBody, nil)...)
+	}
+
+	declared := endpoint.RequestBody.Content[mediaType]
+	if declared == nil {
+		mediaTypes := make([]string, 0, len(endpoint.RequestBody.Content))
+		for declaredType := range endpoint.RequestBody.Content {
+			mediaTypes = append(mediaTypes, declaredType)
+		}
+		sort.Strings(mediaTypes)
+		contradiction(fmt.Sprintf("curl example sends %s but the request body declares %s", mediaType, strings.Join(mediaTypes, ", ")))
+		return parseErrors
+	}
+
+	if declared.Example == nil {
+		declared.Example = schema.Example
+	}
+	if example, ok := schema.Example.(map[string]interface{}); ok {
+		for _, name := range declared.Required {
+			if _, present := example[name]; !present {
+				contradiction(fmt.Sprintf("curl example body is missing required field '%s'", name))
+			}
+		}
+	}
+	return parseErrors
+}
+
+// applyExampleParameter records an example parameter value on the endpoint, adding the parameter when
+// it is not declared. It returns a message describing how the value contradicts the declaration, if it does.
+func applyExampleParameter(endpoint *Endpoint, location, name, value string, lineNumber int) string {
+	for _, parameter := range endpoint.Parameters {
+		if !strings.EqualFold(parameter.Name, name) {
+			continue
+		}
+		if parameter.In != location {
+			return fmt.Sprintf("curl example sends '%s' as a %s parameter but it is declared in %s", name, location, parameter.In)
+		}
+		if message := checkExampleValue(parameter, value); message != "" {
+			return message
+		}
+		if parameter.Example == nil {
+			parameter.Example = parseScalarValue(value, parameterType(parameter))
+		}
+		return ""
+	}
+
+	example := parseScalarValue(value, "")
+	typeName := exampleValueType(example)
+	endpoint.Parameters = append(endpoint.Parameters, &Parameter{
+		Name:       name,
+		In:         location,
+		Type:       typeName,
+		Example:    example,
+		Schema:     SchemaFromTypeName(typeName, lineNumber),
+		LineNumber: lineNumber,
+	})
+	return ""
+}
+
+// checkExampleValue reports an example value that does not fit the declared parameter type or enum
+func checkExampleValue(parameter *Parameter, value string) string {
+	valid := true
+	switch parameter.Type {
+	case "integer":
+		_, err := strconv.ParseInt(value, 10, 64)
+		valid = err == nil
+	case "number":
+		_, err := strconv.ParseFloat(value, 64)
+		valid = err == nil
+	case "boolean":
+		valid = value == "true" || value == "false"
+	}
+	if !valid {
+		return fmt.Sprintf("curl example value %q of parameter '%s' is not a valid %s", value, parameter.Name, parameter.Type)
+	}
+
+	if parameter.Schema == nil || len(parameter.Schema.Enum) == 0 {
+		return ""
+	}
+	for _, allowed := range parameter.Schema.Enum {
+		if fmt.Sprint(allowed) == value {
+			return ""
+		}
+	}
+	return fmt.Sprintf("curl example value %q of parameter '%s' is not one of its allowed values", value, parameter.Name)
+}
+
+// exampleValueType returns the parameter type of a scalar example value
+func exampleValueType(value interface{}) string {
+	switch value.(type) {
+	case int:
+		return "integer"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// bodySchema infers a request body schema from the example payload, keeping the payload as its example.
+// Payloads read from a file with "-d @body.json" give no schema.
+func (r *curlRequest) bodySchema(mediaType string) (*Schema, []*errors.ParseError) {
+	for _, data := range r.data {
+		if strings.HasPrefix(data, "@") {
+			return nil, nil
+		}
+	}
+
+	switch {
+	case len(r.form) > 0:
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema), Inferred: true, LineNumber: r.line}
+		example := make(map[string]interface{})
+		for _, field := range r.form {
+			name, value := field[0], field[1]
+			if strings.HasPrefix(value, "@") {
+				file, _ := fileSchema(curlFileType(value), r.line)
+				schema.SetProperty(name, file)
+				continue
+			}
+			example[name] = parseScalarValue(value, "")
+			schema.SetProperty(name, &Schema{Type: exampleValueType(example[name]), LineNumber: r.line})
+		}
+		if len(example) > 0 {
+			schema.Example = example
+		}
+		return schema, nil
+
+	case mediaType == urlencodedMediaType:
+		values, err := url.ParseQuery(strings.Join(r.data, "&"))
+		if err != nil || len(values) == 0 {
+			return nil, nil
+		}
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema), Inferred: true, LineNumber: r.line}
+		example := make(map[string]interface{})
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			example[name] = parseScalarValue(values.Get(name), "")
+			schema.SetProperty(name, &Schema{Type: exampleValueType(example[name]), LineNumber: r.line})
+		}
+		schema.Example = example
+		return schema, nil
+
+	case isBinaryMediaType(mediaType):
+		return &Schema{Type: "string", ContentMediaType: mediaType, LineNumber: r.line}, nil
+
+	case strings.Contains(mediaType, "json") || strings.Contains(mediaType, "yaml"):
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(strings.Join(r.data, "&")), &node); err != nil || len(node.Content) == 0 {
+			return nil, []*errors.ParseError{errors.NewWarning(errors.ErrorTypeSyntax,
+				"curl example body is not valid "+mediaType).
+				AtLine(r.line).
+				InSource("example").
+				Build()}
+		}
+		builder := &schemaBuilder{firstLine: r.line}
+		schema := builder.infer(node.Content[0], 1)
+		schema.Example = builder.value(node.Content[0])
+		schema.Inferred = true
+		return schema, builder.errors
+
+	default:
+		return &Schema{Type: "string", Example: strings.Join(r.data, "&"), LineNumber: r.line}, nil
+	}
+}
+
+// curlFileType returns the file shorthand type of a "@photo.png;type=image/png" form value
+func curlFileType(value string) string {
+	for _, option := range strings.Split(value, ";")[1:] {
+		if name, mediaType, ok := strings.Cut(option, "="); ok && strings.TrimSpace(name) == "type" {
+			return "file(" + strings.TrimSpace(mediaType) + ")"
+		}
+	}
+	return "file"
+}
+
+// matchesPathTemplate reports whether a concrete request path ends with an endpoint path template,
+// so server base paths such as "/v1" are allowed. Template variables match any single segment.
+func matchesPathTemplate(template, path string) bool {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathSegments) < len(templateSegments) {
+		return false
+	}
+
+	pathSegments = pathSegments[len(pathSegments)-len(templateSegments):]
+	for i, segment := range templateSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			continue
+		}
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+func TestParseCurlCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		command   string
+		method    string
+		url       string
+		mediaType string
+	}{
+		{
+			name:      "explicit method and json body",
+			command:   `curl -X PUT https://api.example.com/users/1 -H "Content-Type: application/json" -d '{"name": "Ada"}'`,
+			method:    "PUT",
+			url:       "https://api.example.com/users/1",
+			mediaType: "application/json",
+		},
+		{
+			name:      "data implies post",
+			command:   "curl --data-urlencode 'q=a b' 'https://api.example.com/search'",
+			method:    "POST",
+			url:       "https://api.example.com/search",
+			mediaType: urlencodedMediaType,
+		},
+		{
+			name:      "line continuations and attached values",
+			command:   "$ curl -sS \\\n  -XDELETE \\\n  --url=https://api.example.com/users/1",
+			method:    "DELETE",
+			url:       "https://api.example.com/users/1",
+			mediaType: "",
+		},
+		{
+			name:      "form fields",
+			command:   `curl -u user:pass -F "file=@a.png" https://api.example.com/uploads`,
+			method:    "POST",
+			url:       "https://api.example.com/uploads",
+			mediaType: multipartMediaType,
+		},
+		{
+			name:      "get moves data into the query",
+			command:   "curl -G -d limit=5 https://api.example.com/users",
+			method:    "GET",
+			url:       "https://api.example.com/users",
+			mediaType: urlencodedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words, err := splitShellWords(tt.command)
+			require.NoError(t, err)
+			commands := splitShellCommands(words)
+			require.Len(t, commands, 1)
+			require.Equal(t, "curl", commands[0][0])
+
+			request, parseErr := parseCurlCommand(commands[0][1:], 1)
+			require.Nil(t, parseErr)
+			assert.Equal(t, tt.method, request.effectiveMethod())
+			assert.Equal(t, tt.url, request.url)
+			assert.Equal(t, tt.mediaType, request.mediaType())
+		})
+	}
+}
+
+func TestParser_ParseCurlExamples(t *testing.T) {
+	content := "## GET /users\n\n" +
+		"### Query Parameters\n\n" +
+		"- **limit** (query, integer) - Page size\n\n" +
+		"### Example\n\n" +
+		"```bash\n" +
+		"curl \"https://api.example.com/v1/users?limit=20&cursor=abc\" \\\n" +
+		"  -H 'X-Request-ID: 42' \\\n" +
+		"  -H 'Authorization: Bearer token'\n" +
+		"```\n\n" +
+		"## POST /users\n\n" +
+		"### Example\n\n" +
+		"```bash\n" +
+		"curl -X POST https://api.example.com/v1/users -d '{\"name\": \"Ada\"}'\n" +
+		"```\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	for _, parseErr := range doc.Errors {
+		assert.NotEqual(t, errors.SeverityError, parseErr.Severity, parseErr.Message)
+	}
+
+	require.Len(t, doc.Endpoints, 2)
+	parameters := doc.Endpoints[0].Parameters
+	require.Len(t, parameters, 3)
+	assert.Equal(t, "limit", parameters[0].Name)
+	assert.Equal(t, 20, parameters[0].Example)
+	assert.Equal(t, &Parameter{
+		Name: "cursor", In: "query", Type: "string", Example: "abc", Schema: &Schema{Type: "string", LineNumber: 10}, LineNumber: 10,
+	}, parameters[1])
+	assert.Equal(t, &Parameter{
+		Name: "X-Request-ID", In: "header", Type: "integer", Example: 42, Schema: &Schema{Type: "integer", LineNumber: 10}, LineNumber: 10,
+	}, parameters[2])
+
+	requestBody := doc.Endpoints[1].RequestBody
+	require.NotNil(t, requestBody)
+	schema := requestBody.Content[defaultMediaType]
+	require.NotNil(t, schema)
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, map[string]interface{}{"name": "Ada"}, schema.Example)
+	assert.Equal(t, "string", schema.Properties["name"].Type)
+}
+
+func TestParser_ParseCurlExampleContradictions(t *testing.T) {
+	content := "## PUT /users/{id}\n\n" +
+		"### Path Parameters\n\n" +
+		"- **id** (path, integer) - User ID\n\n" +
+		"### Query Parameters\n\n" +
+		"- **notify** (query, boolean) - Send an email\n\n" +
+		"### Request Body\n\n" +
+		"Content-Type: application/json\n\n" +
+		"| Name | Type | Required |\n" +
+		"| --- | --- | --- |\n" +
+		"| name | string | yes |\n\n" +
+		"### Example\n\n" +
+		"```bash\n" +
+		"curl -X PATCH 'https://api.example.com/accounts/1?notify=maybe' -H 'notify: yes' -d 'name=Ada'\n" +
+		"```\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, parseErr := range doc.Errors {
+		if parseErr.Type == errors.ErrorTypeValidation && parseErr.Severity == errors.SeverityError {
+			messages = append(messages, parseErr.Message)
+			assert.Equal(t, 22, parseErr.LineNumber)
+		}
+	}
+	assert.Equal(t, []string{
+		"curl example sends PATCH but the endpoint is PUT /users/{id}",
+		"curl example path /accounts/1 does not match /users/{id}",
+		"curl example value \"maybe\" of parameter 'notify' is not a valid boolean",
+		"curl example sends 'notify' as a header parameter but it is declared in query",
+		"curl example sends application/x-www-form-urlencoded but the request body declares application/json",
+	}, messages)
+}
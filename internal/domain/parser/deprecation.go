@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// sunsetDateLayout is the layout of sunset dates, e.g. "2027-01-01"
+const sunsetDateLayout = "2006-01-02"
+
+// deprecationShape is the expected shape of a deprecation, used in suggestions
+const deprecationShape = "**Deprecated:** since 2.3, sunset 2027-01-01, use GET /v2/users"
+
+var (
+	deprecatedLinePattern    = regexp.MustCompile(`(?i)^(?:[-*+]\s+)?(?:\*\*)?deprecated\s*(?:\*\*)?\s*(?::\s*(?:\*\*)?\s*(.*))?$`)
+	deprecationDetailPattern = regexp.MustCompile(`(?i)^(since|sunset|use|replaced by|superseded by)\b\s*:?\s*(?:on\s+)?(.+)$`)
+)
+
+// parseDeprecatedLine reads a "**Deprecated:** ..." line. The second result is false when the line is not one;
+// the deprecation is nil for "**Deprecated:** no".
+func parseDeprecatedLine(trimmed string, lineNumber int) (*Deprecation, []*errors.ParseError, bool) {
+	matches := deprecatedLinePattern.FindStringSubmatch(trimmed)
+	if matches == nil {
+		return nil, nil, false
+	}
+	deprecation, parseErrors := parseDeprecation(matches[1], lineNumber)
+	return deprecation, parseErrors, true
+}
+
+// parseDeprecation reads comma separated deprecation details such as "since 2.3, sunset 2027-01-01, use GET /v2/users".
+// Empty text, "yes" and "true" deprecate without details, "no", "false" and "-" return nil.
+func parseDeprecation(text string, lineNumber int) (*Deprecation, []*errors.ParseError) {
+	text = strings.TrimSpace(text)
+	switch strings.ToLower(text) {
+	case "no", "false", "n", "-":
+		return nil, nil
+	}
+
+	deprecation := &Deprecation{LineNumber: lineNumber}
+	var parseErrors []*errors.ParseError
+
+	for _, part := range splitAttributes(text) {
+		part = strings.TrimSuffix(part, ".")
+		switch strings.ToLower(part) {
+		case "yes", "true", "y", "x", "✓", "✔":
+			continue
+		}
+
+		matches := deprecationDetailPattern.FindStringSubmatch(part)
+		if matches == nil {
+			parseErrors = append(parseErrors, errors.NewWarning(errors.ErrorTypeSyntax,
+				fmt.Sprintf("unknown deprecation detail %q is ignored", part)).
+				AtLine(lineNumber).
+				InSource("deprecation").
+				WithSuggestion("Use the form '"+deprecationShape+"'").
+				Build())
+			continue
+		}
+
+		value := strings.Trim(strings.TrimSpace(matches[2]), "`")
+		switch strings.ToLower(matches[1]) {
+		case "since":
+			deprecation.Since = value
+		case "sunset":
+			if _, err := time.Parse(sunsetDateLayout, value); err != nil {
+				parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeSyntax,
+					fmt.Sprintf("sunset date %q is not a date", value)).
+					AtLine(lineNumber).
+					InSource("deprecation").
+					WithSuggestion("Write sunset dates as YYYY-MM-DD, e.g. 'sunset 2027-01-01'").
+					Build())
+				continue
+			}
+			deprecation.Sunset = value
+		default:
+			deprecation.ReplacedBy = value
+		}
+	}
+
+	return deprecation, parseErrors
+}
+
+// deprecation reads a schema "deprecated" keyword, either a boolean or deprecation details
+func (b *schemaBuilder) deprecation(node *yaml.Node) *Deprecation {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!bool" {
+		if !b.flag("deprecated", node) {
+			return nil
+		}
+		return &Deprecation{LineNumber: b.lineOf(node)}
+	}
+	if node.Kind != yaml.ScalarNode {
+		b.errors = append(b.errors, errors.NewSchemaError("'deprecated' must be true, false or deprecation details", b.lineOf(node)))
+		return nil
+	}
+
+	deprecation, parseErrors := parseDeprecation(node.Value, b.lineOf(node))
+	b.errors = append(b.errors, parseErrors...)
+	return deprecation
+}
+
+// sunsetPassed reports whether the sunset date of a deprecation is before now
+func sunsetPassed(deprecation *Deprecation, now time.Time) bool {
+	if deprecation == nil || deprecation.Sunset == "" {
+		return false
+	}
+	sunset, err := time.Parse(sunsetDateLayout, deprecation.Sunset)
+	return err == nil && sunset.Before(now)
+}
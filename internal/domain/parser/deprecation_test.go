@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		expected    *Deprecation
+		errorTypes  []errors.ErrorType
+		errorLevels []errors.Severity
+	}{
+		{
+			name:     "all details",
+			text:     "since 2.3, sunset 2027-01-01, use GET /v2/users",
+			expected: &Deprecation{Since: "2.3", Sunset: "2027-01-01", ReplacedBy: "GET /v2/users", LineNumber: 7},
+		},
+		{
+			name:     "alternative wording",
+			text:     "yes, sunset on `2027-06-30`, replaced by `page_size`.",
+			expected: &Deprecation{Sunset: "2027-06-30", ReplacedBy: "page_size", LineNumber: 7},
+		},
+		{
+			name:     "without details",
+			text:     "",
+			expected: &Deprecation{LineNumber: 7},
+		},
+		{
+			name:     "not deprecated",
+			text:     "no",
+			expected: nil,
+		},
+		{
+			name:        "invalid sunset and unknown detail",
+			text:        "sunset next year, ask the billing team",
+			expected:    &Deprecation{LineNumber: 7},
+			errorTypes:  []errors.ErrorType{errors.ErrorTypeSyntax, errors.ErrorTypeSyntax},
+			errorLevels: []errors.Severity{errors.SeverityError, errors.SeverityWarning},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deprecation, parseErrors := parseDeprecation(tt.text, 7)
+			assert.Equal(t, tt.expected, deprecation)
+
+			var types []errors.ErrorType
+			var levels []errors.Severity
+			for _, parseErr := range parseErrors {
+				types = append(types, parseErr.Type)
+				levels = append(levels, parseErr.Severity)
+			}
+			assert.Equal(t, tt.errorTypes, types)
+			assert.Equal(t, tt.errorLevels, levels)
+		})
+	}
+}
+
+func TestParser_ParseDeprecations(t *testing.T) {
+	content := "## GET /users\n\n" +
+		"List users.\n\n" +
+		"**Deprecated:** since 2.3, sunset 2027-01-01, use GET /v2/users\n\n" +
+		"### Query Parameters\n\n" +
+		"- **page** (query, integer, deprecated) - Page number\n" +
+		"- **offset** (query, integer) - Offset\n" +
+		"  **Deprecated:** use cursor\n\n" +
+		"### Response 200\n\n" +
+		"| Field | Type | Deprecated |\n" +
+		"| --- | --- | --- |\n" +
+		"| id | string | |\n" +
+		"| login | string | sunset 2027-01-01 |\n\n" +
+		"```yaml\n" +
+		"type: object\n" +
+		"properties:\n" +
+		"  name:\n" +
+		"    type: string\n" +
+		"    deprecated: true\n" +
+		"```\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	assert.Empty(t, doc.Errors)
+
+	require.Len(t, doc.Endpoints, 1)
+	endpoint := doc.Endpoints[0]
+	assert.Equal(t, "List users.", endpoint.Description)
+	assert.Equal(t, &Deprecation{Since: "2.3", Sunset: "2027-01-01", ReplacedBy: "GET /v2/users", LineNumber: 5}, endpoint.Deprecated)
+
+	require.Len(t, endpoint.Parameters, 2)
+	assert.Equal(t, &Deprecation{LineNumber: 9}, endpoint.Parameters[0].Deprecated)
+	assert.Equal(t, &Deprecation{ReplacedBy: "cursor", LineNumber: 11}, endpoint.Parameters[1].Deprecated)
+	assert.Equal(t, "Offset", endpoint.Parameters[1].Description)
+
+	require.Len(t, endpoint.Responses, 1)
+	properties := endpoint.Responses[0].Content[defaultMediaType].Properties
+	assert.Nil(t, properties["id"].Deprecated)
+	assert.Equal(t, &Deprecation{Sunset: "2027-01-01", LineNumber: 18}, properties["login"].Deprecated)
+	assert.Equal(t, &Deprecation{LineNumber: 25}, properties["name"].Deprecated)
+}
+
+func TestValidateDocument_PastSunset(t *testing.T) {
+	content := "## GET /users\n\n" +
+		"**Deprecated:** sunset 2020-01-01\n\n" +
+		"### Query Parameters\n\n" +
+		"- **page** (query, integer) - Page number\n" +
+		"  **Deprecated:** sunset 2999-01-01\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+
+	var warnings []*errors.ParseError
+	for _, validationErr := range ValidateDocument(context.Background(), doc, false) {
+		if validationErr.IsWarning() {
+			warnings = append(warnings, validationErr)
+		}
+	}
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "endpoint GET /users is past its sunset date 2020-01-01", warnings[0].Message)
+	assert.Equal(t, 3, warnings[0].LineNumber)
+}
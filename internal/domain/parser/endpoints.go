@@ -0,0 +1,294 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+// endpointHeadingLevel is the heading level used for endpoint definitions (## METHOD /path)
+const endpointHeadingLevel = 2
+
+var endpointTitlePattern = regexp.MustCompile(`^([A-Za-z][A-Za-z_]*)\s+(/\S*)$`)
+
+// knownHTTPMethods lists the methods used to recognise malformed endpoint headings
+var knownHTTPMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "TRACE"}
+
+// parseEndpoints parses endpoints from the section tree.
+// Endpoints without explicit tags are tagged with their enclosing top-level heading.
+// Malformed endpoint sections are skipped and parsing resumes at the next "##" heading.
+// Parsing stops once MaxRecoveryAttempts consecutive resyncs also land on malformed sections,
+// or at the first malformed section when recovery is disabled, and when ctx is done.
+func (p *Parser) parseEndpoints(ctx context.Context, root *section) ([]*Endpoint, []*SkippedEndpoint, []*errors.ParseError) {
+	endpoints := make([]*Endpoint, 0, p.config.InitialSliceCapacity)
+	var skipped []*SkippedEndpoint
+	var parseErrors []*errors.ParseError
+	failures := 0
+	stoppedAt := 0
+
+	var walk func(sec *section, group string)
+	walk = func(sec *section, group string) {
+		for _, child := range sec.children {
+			if ctx.Err() != nil {
+				return
+			}
+			if child.level != endpointHeadingLevel {
+				if isTagGroupSection(child) {
+					walk(child, tagName(child.title))
+				} else {
+					walk(child, group)
+				}
+				continue
+			}
+
+			if stoppedAt > 0 {
+				if endpointTitlePattern.MatchString(child.title) {
+					skipped = append(skipped, &SkippedEndpoint{
+						Heading:    child.title,
+						Reason:     fmt.Sprintf("parsing stopped at line %d", stoppedAt),
+						LineNumber: child.line,
+					})
+				}
+				continue
+			}
+
+			endpoint, errs := p.parseEndpoint(ctx, child)
+			parseErrors = append(parseErrors, errs...)
+			if endpoint != nil {
+				if endpoint.Tags == nil && group != "" {
+					endpoint.Tags = []string{group}
+				}
+				endpoints = append(endpoints, endpoint)
+				failures = 0
+				continue
+			}
+			if len(errs) == 0 {
+				// Not an endpoint heading
+				continue
+			}
+
+			skipped = append(skipped, &SkippedEndpoint{Heading: child.title, Reason: errs[0].Message, LineNumber: child.line})
+			failures++
+
+			switch {
+			case !p.config.EnableRecovery:
+				stoppedAt = child.line
+				parseErrors = append(parseErrors, errors.NewFatal(errors.ErrorTypeEndpoint,
+					"stopped at the first malformed endpoint because error recovery is disabled").
+					AtLine(child.line).
+					InSource("endpoint").
+					WithSuggestion("Fix the endpoint above or enable recovery to keep parsing past it").
+					Build())
+			case failures > p.config.MaxRecoveryAttempts:
+				stoppedAt = child.line
+				parseErrors = append(parseErrors, errors.NewFatal(errors.ErrorTypeEndpoint,
+					fmt.Sprintf("giving up after %d failed recovery attempts", p.config.MaxRecoveryAttempts)).
+					AtLine(child.line).
+					InSource("endpoint").
+					WithSuggestion("Fix the malformed endpoints above or raise max_recovery_attempts").
+					Build())
+			}
+		}
+	}
+	walk(root, "")
+
+	return endpoints, skipped, parseErrors
+}
+
+// parseEndpoint parses a single "## METHOD /path" section.
+// It returns a nil endpoint when the heading is not an endpoint definition.
+func (p *Parser) parseEndpoint(ctx context.Context, sec *section) (*Endpoint, []*errors.ParseError) {
+	matches := endpointTitlePattern.FindStringSubmatch(sec.title)
+	if matches == nil {
+		if err := p.checkMalformedEndpointHeading(sec); err != nil {
+			return nil, []*errors.ParseError{err}
+		}
+		return nil, nil
+	}
+
+	if fenceLine := findUnterminatedFence(sec); fenceLine > 0 {
+		return nil, []*errors.ParseError{unterminatedFenceError(fenceLine, "endpoint")}
+	}
+
+	endpoint := &Endpoint{
+		Method:     strings.ToUpper(matches[1]),
+		Path:       matches[2],
+		Parameters: []*Parameter{},
+		Responses:  []*Response{},
+		LineNumber: sec.line,
+	}
+
+	parseErrors := p.parseOperation(ctx, endpoint, sec)
+	if endpoint.OperationID == "" {
+		endpoint.OperationID = deriveOperationID(endpoint.Method, endpoint.Path, p.config.OperationIDStyle)
+	}
+
+	return endpoint, parseErrors
+}
+
+// parseOperation fills an endpoint, webhook or callback operation from its section body and subsections
+func (p *Parser) parseOperation(ctx context.Context, operation *Endpoint, sec *section) []*errors.ParseError {
+	// A "**Request Body (multipart/form-data):**" label claims the rest of the body
+	body := sec.body
+	var parseErrors []*errors.ParseError
+	if index, title := findRequestBodyLabel(body); index >= 0 {
+		requestBody, errs := p.parseRequestBodySection(&section{title: title, line: body[index].number, body: body[index+1:]})
+		operation.RequestBody = requestBody
+		parseErrors = append(parseErrors, errs...)
+		body = body[:index]
+	}
+
+	body, errs := p.extractEndpointMetadata(operation, body)
+	parseErrors = append(parseErrors, errs...)
+	prose := paragraphs(body)
+	if len(prose) > 0 {
+		operation.Summary = prose[0]
+		operation.Description = strings.Join(prose, "\n\n")
+	}
+
+	return append(parseErrors, p.parseEndpointSubsections(ctx, operation, sec)...)
+}
+
+// unterminatedFenceError reports a code fence that swallows the rest of a section
+func unterminatedFenceError(lineNumber int, source string) *errors.ParseError {
+	return errors.NewError(errors.ErrorTypeSyntax, "code fence is never closed").
+		AtLine(lineNumber).
+		InSource(source).
+		WithSuggestion("Close the block with a matching ``` or ~~~ line").
+		Build()
+}
+
+// extractEndpointMetadata reads "**Auth:**", "**Tags:**", "**Operation ID:**", "**Deprecated:**" and
+// "**x-name:**" lines into the endpoint and returns the remaining body lines
+func (p *Parser) extractEndpointMetadata(endpoint *Endpoint, body []line) ([]line, []*errors.ParseError) {
+	remaining := make([]line, 0, len(body))
+	var parseErrors []*errors.ParseError
+	fence := ""
+
+	for _, l := range body {
+		if marker := fenceMarker(l.text); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if marker == fence {
+				fence = ""
+			}
+		}
+		if fence == "" {
+			trimmed := strings.TrimSpace(l.text)
+			if matches := authLinePattern.FindStringSubmatch(trimmed); matches != nil {
+				endpoint.Security = parseSecurityRequirements(matches[1], l.number)
+				continue
+			}
+			if matches := tagsLinePattern.FindStringSubmatch(trimmed); matches != nil {
+				endpoint.Tags = parseTagList(matches[1])
+				continue
+			}
+			if matches := operationIDLinePattern.FindStringSubmatch(trimmed); matches != nil {
+				endpoint.OperationID = matches[1]
+				continue
+			}
+			if key, value, ok := parseExtensionLine(trimmed); ok {
+				endpoint.Extensions = setExtension(endpoint.Extensions, key, value)
+				continue
+			}
+			if deprecation, errs, ok := parseDeprecatedLine(trimmed, l.number); ok {
+				endpoint.Deprecated = deprecation
+				parseErrors = append(parseErrors, errs...)
+				continue
+			}
+		}
+		remaining = append(remaining, l)
+	}
+
+	return remaining, parseErrors
+}
+
+// parseEndpointSubsections dispatches the "###" subsections of an endpoint to their parsers until ctx is done.
+// Registered section parsers take precedence over the built-in ones.
+func (p *Parser) parseEndpointSubsections(ctx context.Context, endpoint *Endpoint, sec *section) []*errors.ParseError {
+	var parseErrors []*errors.ParseError
+
+	for _, sub := range sec.children {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if sectionParser := p.sectionParser(sub.title); sectionParser != nil {
+			extensions, err := p.runSectionParser(ctx, sectionParser, sub, endpoint.Extensions)
+			endpoint.Extensions = extensions
+			if err != nil {
+				parseErrors = append(parseErrors, err)
+			}
+			continue
+		}
+
+		if location, ok := parameterSectionLocation(sub.title); ok {
+			parameters, errs := p.parseParameterSection(sub, location)
+			endpoint.Parameters = append(endpoint.Parameters, parameters...)
+			parseErrors = append(parseErrors, errs...)
+			continue
+		}
+
+		if isRequestBodySection(sub.title) {
+			requestBody, errs := p.parseRequestBodySection(sub)
+			endpoint.RequestBody = requestBody
+			parseErrors = append(parseErrors, errs...)
+			continue
+		}
+
+		if isExampleSection(sub.title) {
+			examples, errs := parseExampleSection(sub)
+			endpoint.examples = append(endpoint.examples, examples...)
+			parseErrors = append(parseErrors, errs...)
+			continue
+		}
+
+		if isCallbacksSection(sub.title) {
+			callbacks, errs := p.parseCallbacksSection(ctx, sub)
+			endpoint.Callbacks = append(endpoint.Callbacks, callbacks...)
+			parseErrors = append(parseErrors, errs...)
+			continue
+		}
+
+		if normalizeTitle(sub.title) == "responses" {
+			responses, errs := p.parseResponsesSection(sub)
+			endpoint.Responses = append(endpoint.Responses, responses...)
+			parseErrors = append(parseErrors, errs...)
+			continue
+		}
+
+		if response, errs, ok := p.parseResponseSection(sub); ok {
+			endpoint.Responses = append(endpoint.Responses, response)
+			parseErrors = append(parseErrors, errs...)
+		}
+	}
+
+	return parseErrors
+}
+
+// checkMalformedEndpointHeading reports headings that start with an upper-case HTTP method but lack a valid path
+func (p *Parser) checkMalformedEndpointHeading(sec *section) *errors.ParseError {
+	fields := strings.Fields(sec.title)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	method := fields[0]
+	if !isKnownHTTPMethod(method) {
+		return nil
+	}
+
+	message := fmt.Sprintf("endpoint heading %q is missing a path", sec.title)
+	if len(fields) > 1 {
+		message = fmt.Sprintf("endpoint path %q must start with /", fields[1])
+	}
+
+	return errors.NewError(errors.ErrorTypeEndpoint, message).
+		AtLine(sec.line).
+		InSource("endpoint").
+		WithSuggestion(fmt.Sprintf("Use the form '## %s /resource/{id}'", method)).
+		Build()
+}
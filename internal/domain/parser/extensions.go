@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// extensionPrefix is the prefix OpenAPI requires of vendor extension keys
+const extensionPrefix = "x-"
+
+// extensionLinePattern matches "**x-name:** value" lines. Only lower-case names are recognised so that
+// prose such as "X-Request-ID: abc" describing a header is left alone.
+var extensionLinePattern = regexp.MustCompile(`^(?:[-*+]\s+)?(?:\*\*)?(x-[\w.-]+)\s*(?:\*\*)?\s*:\s*(?:\*\*)?\s*(.+)$`)
+
+// isExtensionKey reports whether key names a vendor extension
+func isExtensionKey(key string) bool {
+	return len(key) > len(extensionPrefix) && strings.HasPrefix(key, extensionPrefix)
+}
+
+// setExtension stores a vendor extension, allocating the map on first use, and returns the map
+func setExtension(extensions map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	if extensions == nil {
+		extensions = make(map[string]interface{})
+	}
+	extensions[key] = value
+	return extensions
+}
+
+// parseExtensionLine reads a "**x-name:** value" line. The value is typed like other inline values,
+// so "true", "42" and JSON arrays or objects keep their type.
+func parseExtensionLine(trimmed string) (string, interface{}, bool) {
+	matches := extensionLinePattern.FindStringSubmatch(trimmed)
+	if matches == nil {
+		return "", nil, false
+	}
+	return matches[1], parseScalarValue(matches[2], ""), true
+}
+
+// parseExtensionAttribute reads an "x-name: value" parameter attribute. A bare "x-name" is true.
+func parseExtensionAttribute(attribute string) (string, interface{}, bool) {
+	key, value, hasValue := strings.Cut(attribute, ":")
+	key = strings.TrimSpace(key)
+	if !isExtensionKey(key) || strings.ContainsAny(key, " \t") {
+		return "", nil, false
+	}
+	if !hasValue {
+		return key, true, true
+	}
+	return key, parseScalarValue(value, ""), true
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExtensionAttribute(t *testing.T) {
+	tests := []struct {
+		attribute string
+		key       string
+		value     interface{}
+		ok        bool
+	}{
+		{attribute: "x-internal", key: "x-internal", value: true, ok: true},
+		{attribute: "x-rate-limit: 100", key: "x-rate-limit", value: 100, ok: true},
+		{attribute: "x-owners: `[\"billing\"]`", key: "x-owners", value: []interface{}{"billing"}, ok: true},
+		{attribute: "x-", ok: false},
+		{attribute: "X-Request-ID", ok: false},
+		{attribute: "required", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.attribute, func(t *testing.T) {
+			key, value, ok := parseExtensionAttribute(tt.attribute)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.key, key)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
+
+func TestParser_ParseExtensions(t *testing.T) {
+	content := "---\n" +
+		"title: Billing API\n" +
+		"x-audience: partner\n" +
+		"---\n\n" +
+		"## POST /invoices\n\n" +
+		"Create an invoice.\n\n" +
+		"**x-rate-limit:** 100\n\n" +
+		"### Query Parameters\n\n" +
+		"- **dry_run** (query, boolean, x-internal) - Validate only\n\n" +
+		"| Name | Type | x-stability |\n" +
+		"| --- | --- | --- |\n" +
+		"| currency | string | beta |\n\n" +
+		"### Request Body\n\n" +
+		"**x-codegen-name:** InvoiceInput\n\n" +
+		"```yaml\n" +
+		"type: object\n" +
+		"x-tables: [invoices]\n" +
+		"properties:\n" +
+		"  amount:\n" +
+		"    type: integer\n" +
+		"    x-unit: cents\n" +
+		"```\n\n" +
+		"### Response 201\n\n" +
+		"Invoice created\n\n" +
+		"- **x-cache:** `{\"ttl\": 60}`\n\n" +
+		"| Field | Type | x-pii |\n" +
+		"| --- | --- | --- |\n" +
+		"| email | string | true |\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	assert.Empty(t, doc.Errors)
+
+	assert.Equal(t, map[string]interface{}{"x-audience": "partner"}, doc.Extensions)
+
+	require.Len(t, doc.Endpoints, 1)
+	endpoint := doc.Endpoints[0]
+	assert.Equal(t, "Create an invoice.", endpoint.Description)
+	assert.Equal(t, map[string]interface{}{"x-rate-limit": 100}, endpoint.Extensions)
+
+	require.Len(t, endpoint.Parameters, 2)
+	assert.Equal(t, map[string]interface{}{"x-internal": true}, endpoint.Parameters[0].Extensions)
+	assert.Equal(t, "boolean", endpoint.Parameters[0].Type)
+	assert.Equal(t, map[string]interface{}{"x-stability": "beta"}, endpoint.Parameters[1].Extensions)
+
+	require.NotNil(t, endpoint.RequestBody)
+	assert.Equal(t, map[string]interface{}{"x-codegen-name": "InvoiceInput"}, endpoint.RequestBody.Extensions)
+	schema := endpoint.RequestBody.Content[defaultMediaType]
+	require.NotNil(t, schema)
+	assert.Equal(t, map[string]interface{}{"x-tables": []interface{}{"invoices"}}, schema.Extensions)
+	assert.Equal(t, map[string]interface{}{"x-unit": "cents"}, schema.Properties["amount"].Extensions)
+
+	require.Len(t, endpoint.Responses, 1)
+	response := endpoint.Responses[0]
+	assert.Equal(t, "Invoice created", response.Description)
+	assert.Equal(t, map[string]interface{}{"x-cache": map[string]interface{}{"ttl": float64(60)}}, response.Extensions)
+	assert.Equal(t, map[string]interface{}{"x-pii": true}, response.Content[defaultMediaType].Properties["email"].Extensions)
+}
+
+func TestParser_ExtensionColumnsOnlyWhereSupported(t *testing.T) {
+	content := "## GET /invoices\n\n" +
+		"### Response 200\n\n" +
+		"##### Headers\n\n" +
+		"| Name | Type | x-internal |\n" +
+		"| --- | --- | --- |\n" +
+		"| X-Total | integer | true |\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Errors, 1)
+	assert.Equal(t, `unknown table column "x-internal" is ignored`, doc.Errors[0].Message)
+	assert.Equal(t, 7, doc.Errors[0].LineNumber)
+}
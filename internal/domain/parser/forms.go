@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+const (
+	multipartMediaType  = "multipart/form-data"
+	urlencodedMediaType = "application/x-www-form-urlencoded"
+	binaryMediaType     = "application/octet-stream"
+)
+
+var (
+	requestBodyTitlePattern = regexp.MustCompile("(?i)^(?:request body|request|body|request payload)\\s*(?:\\(\\s*`?([\\w.+-]+/[\\w.+*-]+)`?\\s*\\))?$")
+	fileTypePattern         = regexp.MustCompile(`^(file|binary|base64)(?:\s*\(\s*([^)]*?)\s*\))?$`)
+)
+
+// requestBodyMediaType parses a "Request Body (multipart/form-data)" title.
+// The media type is empty when the title does not name one; the second result is false
+// when the title does not introduce a request body.
+func requestBodyMediaType(title string) (string, bool) {
+	matches := requestBodyTitlePattern.FindStringSubmatch(strings.TrimSuffix(strings.TrimSpace(title), ":"))
+	if matches == nil {
+		return "", false
+	}
+	return strings.ToLower(matches[1]), true
+}
+
+// findRequestBodyLabel finds a "**Request Body (multipart/form-data):**" label line in an endpoint body.
+// It returns the index of the label and the title it names, or -1 when there is none.
+func findRequestBodyLabel(body []line) (int, string) {
+	fence := ""
+	for i, l := range body {
+		if marker := fenceMarker(l.text); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if marker == fence {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(l.text)
+		if isListItem(trimmed) {
+			continue
+		}
+		if name, ok := parseLabel(trimmed); ok {
+			if _, ok := requestBodyMediaType(name); ok {
+				return i, name
+			}
+		}
+	}
+	return -1, ""
+}
+
+// isFormMediaType reports whether a media type carries form fields
+func isFormMediaType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "multipart/") || mediaType == urlencodedMediaType
+}
+
+// isBinaryMediaType reports whether a media type carries raw bytes rather than structured data
+func isBinaryMediaType(mediaType string) bool {
+	switch {
+	case mediaType == binaryMediaType, mediaType == "application/pdf", mediaType == "application/zip":
+		return true
+	case strings.HasPrefix(mediaType, "image/"), strings.HasPrefix(mediaType, "audio/"), strings.HasPrefix(mediaType, "video/"):
+		return true
+	default:
+		return false
+	}
+}
+
+// fileSchema builds the schema of a "file", "binary", "file(image/png)" or "base64" shorthand type.
+// The second result is false when the type is not a file type.
+func fileSchema(typeName string, lineNumber int) (*Schema, bool) {
+	matches := fileTypePattern.FindStringSubmatch(typeName)
+	if matches == nil {
+		return nil, false
+	}
+
+	schema := &Schema{Type: "string", ContentMediaType: binaryMediaType, LineNumber: lineNumber}
+	if matches[2] != "" {
+		schema.ContentMediaType = matches[2]
+	}
+	if matches[1] == "base64" {
+		schema.ContentEncoding = "base64"
+	}
+	return schema, true
+}
+
+// isFileSchema reports whether a schema describes raw file content
+func isFileSchema(schema *Schema) bool {
+	return schema != nil && schema.ContentMediaType != "" && schema.ContentEncoding == ""
+}
+
+// completeRequestBody fills in media types that were declared without a schema and adapts
+// file fields to each media type. Multipart bodies get encoding entries for files with a
+// specific content type, JSON-like bodies carry files base64 encoded, and urlencoded forms
+// cannot carry files at all.
+func completeRequestBody(requestBody *RequestBody, declared []string) []*errors.ParseError {
+	for _, mediaType := range declared {
+		if _, ok := requestBody.Content[mediaType]; ok {
+			continue
+		}
+		schema := &Schema{LineNumber: requestBody.LineNumber}
+		if isBinaryMediaType(mediaType) {
+			schema.Type = "string"
+			schema.ContentMediaType = mediaType
+		}
+		requestBody.Content[mediaType] = schema
+	}
+
+	var parseErrors []*errors.ParseError
+	for mediaType, schema := range requestBody.Content {
+		if isBinaryMediaType(mediaType) {
+			continue
+		}
+
+		for _, name := range schema.PropertyNames() {
+			field := schema.Properties[name]
+			file := field
+			if field.Type == "array" {
+				file = field.Items
+			}
+			if !isFileSchema(file) {
+				continue
+			}
+
+			switch {
+			case mediaType == urlencodedMediaType:
+				parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeValidation,
+					fmt.Sprintf("field '%s' is a file, which %s bodies cannot carry", name, urlencodedMediaType)).
+					AtLine(field.LineNumber).
+					InSource("request_body").
+					WithSuggestion("Use '"+multipartMediaType+"' for file uploads").
+					Build())
+			case strings.HasPrefix(mediaType, "multipart/"):
+				if file.ContentMediaType == binaryMediaType {
+					continue
+				}
+				if requestBody.Encoding == nil {
+					requestBody.Encoding = make(map[string]*Encoding)
+				}
+				requestBody.Encoding[name] = &Encoding{ContentType: file.ContentMediaType}
+			default:
+				file.ContentEncoding = "base64"
+			}
+		}
+	}
+
+	return parseErrors
+}
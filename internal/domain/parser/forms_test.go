@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+func TestParser_ParseFormRequestBodies(t *testing.T) {
+	content := "## POST /users/{id}/avatar\n\n" +
+		"Upload an avatar.\n\n" +
+		"**Request Body (multipart/form-data):**\n\n" +
+		"Profile picture and metadata.\n\n" +
+		"| Name | Type | Required |\n" +
+		"| --- | --- | --- |\n" +
+		"| avatar | file(image/png) | yes |\n" +
+		"| attachments | file[] | no |\n" +
+		"| caption | string | no |\n\n" +
+		"## PUT /blobs/{id}\n\n" +
+		"### Request Body (application/octet-stream)\n\n" +
+		"## POST /documents\n\n" +
+		"### Request Body\n\n" +
+		"| Name | Type |\n" +
+		"| --- | --- |\n" +
+		"| document | binary |\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	assert.Empty(t, doc.Errors)
+	require.Len(t, doc.Endpoints, 3)
+
+	upload := doc.Endpoints[0]
+	assert.Equal(t, "Upload an avatar.", upload.Description)
+	require.NotNil(t, upload.RequestBody)
+	assert.Equal(t, "Profile picture and metadata.", upload.RequestBody.Description)
+	form := upload.RequestBody.Content[multipartMediaType]
+	require.NotNil(t, form)
+	assert.Equal(t, []string{"avatar"}, form.Required)
+	assert.Equal(t, &Schema{Type: "string", ContentMediaType: "image/png", LineNumber: 11}, form.Properties["avatar"])
+	assert.Equal(t, binaryMediaType, form.Properties["attachments"].Items.ContentMediaType)
+	assert.Equal(t, map[string]*Encoding{"avatar": {ContentType: "image/png"}}, upload.RequestBody.Encoding)
+
+	blob := doc.Endpoints[1].RequestBody
+	require.NotNil(t, blob)
+	assert.Equal(t, &Schema{Type: "string", ContentMediaType: binaryMediaType, LineNumber: 17},
+		blob.Content[binaryMediaType])
+
+	document := doc.Endpoints[2].RequestBody.Content[defaultMediaType].Properties["document"]
+	assert.Equal(t, "base64", document.ContentEncoding)
+}
+
+func TestParser_ParseUrlencodedFileField(t *testing.T) {
+	content := "## POST /login\n\n" +
+		"### Request Body (application/x-www-form-urlencoded)\n\n" +
+		"| Name | Type |\n" +
+		"| --- | --- |\n" +
+		"| user | string |\n" +
+		"| key | file |\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Errors, 1)
+	assert.Equal(t, errors.ErrorTypeValidation, doc.Errors[0].Type)
+	assert.Equal(t, "field 'key' is a file, which application/x-www-form-urlencoded bodies cannot carry", doc.Errors[0].Message)
+	assert.Equal(t, 8, doc.Errors[0].LineNumber)
+
+	form := doc.Endpoints[0].RequestBody.Content[urlencodedMediaType]
+	require.NotNil(t, form)
+	assert.Len(t, form.Properties, 2)
+}
@@ -0,0 +1,219 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// frontmatterDelimiter opens and closes the YAML frontmatter block
+const frontmatterDelimiter = "---"
+
+var yamlErrorLinePattern = regexp.MustCompile(`^line (\d+): (.*)$`)
+
+// parseFrontmatter parses YAML frontmatter from the start of the document.
+// It returns the frontmatter (nil when absent) and the lines that follow it.
+func (p *Parser) parseFrontmatter(lines []line) (*Frontmatter, []line, []*errors.ParseError) {
+	if len(lines) == 0 || strings.TrimSpace(strings.TrimPrefix(lines[0].text, "\ufeff")) != frontmatterDelimiter {
+		return nil, lines, nil
+	}
+
+	closing := -1
+	for i := 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i].text)
+		if trimmed == frontmatterDelimiter || trimmed == "..." {
+			closing = i
+			break
+		}
+	}
+
+	if closing == -1 {
+		return nil, lines, []*errors.ParseError{
+			errors.NewError(errors.ErrorTypeFrontmatter, "frontmatter block is not closed").
+				AtLine(lines[0].number).
+				InSource("frontmatter").
+				WithSuggestion("Add a closing '---' line after the frontmatter").
+				Build(),
+		}
+	}
+
+	openLine := lines[0].number
+	block := make([]string, 0, closing-1)
+	for _, l := range lines[1:closing] {
+		block = append(block, l.text)
+	}
+
+	frontmatter := &Frontmatter{LineNumber: openLine}
+	remaining := lines[closing+1:]
+
+	if strings.TrimSpace(strings.Join(block, "")) == "" {
+		return frontmatter, remaining, nil
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(block, "\n")), &root); err != nil {
+		return nil, remaining, []*errors.ParseError{yamlError(err, openLine)}
+	}
+
+	if len(root.Content) == 0 {
+		return frontmatter, remaining, nil
+	}
+
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, remaining, []*errors.ParseError{
+			errors.NewFrontmatterError("frontmatter must be a YAML mapping of keys to values", openLine+mapping.Line),
+		}
+	}
+
+	parseErrors := decodeFrontmatter(mapping, frontmatter, openLine)
+
+	return frontmatter, remaining, parseErrors
+}
+
+// decodeFrontmatter populates the frontmatter from a YAML mapping node.
+// Node line numbers are relative to the block, so openLine is added to report document lines.
+func decodeFrontmatter(mapping *yaml.Node, frontmatter *Frontmatter, openLine int) []*errors.ParseError {
+	var parseErrors []*errors.ParseError
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+
+		switch key.Value {
+		case "title", "version", "description":
+			if value.Kind != yaml.ScalarNode {
+				parseErrors = append(parseErrors, errors.NewFrontmatterError(
+					fmt.Sprintf("frontmatter field '%s' must be a string", key.Value), openLine+value.Line))
+				continue
+			}
+			switch key.Value {
+			case "title":
+				frontmatter.Title = value.Value
+			case "version":
+				frontmatter.Version = value.Value
+			case "description":
+				frontmatter.Description = value.Value
+			}
+		case "servers":
+			servers, errs := decodeServers(value, openLine)
+			frontmatter.Servers = servers
+			parseErrors = append(parseErrors, errs...)
+		case "securitySchemes", "security_schemes":
+			schemes, errs := decodeSecuritySchemes(value, openLine)
+			frontmatter.SecuritySchemes = schemes
+			parseErrors = append(parseErrors, errs...)
+		case "security", "auth":
+			frontmatter.Security = decodeSecurityRequirements(value, openLine)
+		default:
+			if isExtensionKey(key.Value) {
+				var extension interface{}
+				if err := value.Decode(&extension); err != nil {
+					parseErrors = append(parseErrors, errors.NewFrontmatterError(
+						fmt.Sprintf("cannot read frontmatter extension '%s': %v", key.Value, err), openLine+value.Line))
+					continue
+				}
+				frontmatter.Extensions = setExtension(frontmatter.Extensions, key.Value, extension)
+				continue
+			}
+
+			if frontmatter.Metadata == nil {
+				frontmatter.Metadata = make(map[string]string)
+			}
+			metadata, err := metadataValue(value)
+			if err != nil {
+				parseErrors = append(parseErrors, errors.NewFrontmatterError(
+					fmt.Sprintf("cannot read frontmatter field '%s': %v", key.Value, err), openLine+value.Line))
+				continue
+			}
+			frontmatter.Metadata[key.Value] = metadata
+		}
+	}
+
+	return parseErrors
+}
+
+// decodeServers decodes the servers list, accepting either URL strings or url/description mappings
+func decodeServers(node *yaml.Node, openLine int) ([]Server, []*errors.ParseError) {
+	if node.Kind != yaml.SequenceNode {
+		return nil, []*errors.ParseError{
+			errors.NewError(errors.ErrorTypeFrontmatter, "frontmatter field 'servers' must be a list").
+				AtLine(openLine + node.Line).
+				InSource("frontmatter").
+				WithSuggestion("Use '- url: https://api.example.com' entries").
+				Build(),
+		}
+	}
+
+	servers := make([]Server, 0, len(node.Content))
+	var parseErrors []*errors.ParseError
+
+	for _, item := range node.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			servers = append(servers, Server{URL: item.Value})
+		case yaml.MappingNode:
+			var server Server
+			for i := 0; i+1 < len(item.Content); i += 2 {
+				switch item.Content[i].Value {
+				case "url":
+					server.URL = item.Content[i+1].Value
+				case "description":
+					server.Description = item.Content[i+1].Value
+				}
+			}
+			if server.URL == "" {
+				parseErrors = append(parseErrors, errors.NewFrontmatterError("server entry is missing 'url'", openLine+item.Line))
+				continue
+			}
+			servers = append(servers, server)
+		default:
+			parseErrors = append(parseErrors, errors.NewFrontmatterError("server entry must be a URL or a url/description mapping", openLine+item.Line))
+		}
+	}
+
+	return servers, parseErrors
+}
+
+// metadataValue converts an unknown frontmatter value to its string form.
+// Scalars keep their literal text; lists and mappings are stored as JSON.
+func metadataValue(node *yaml.Node) (string, error) {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value, nil
+	}
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// yamlError converts a YAML syntax error into a parse error pointing at the document line
+func yamlError(err error, openLine int) *errors.ParseError {
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	lineNumber := openLine
+
+	if matches := yamlErrorLinePattern.FindStringSubmatch(message); matches != nil {
+		if n, convErr := strconv.Atoi(matches[1]); convErr == nil {
+			lineNumber = openLine + n
+		}
+		message = matches[2]
+	}
+
+	return errors.NewError(errors.ErrorTypeFrontmatter, "invalid YAML: "+message).
+		AtLine(lineNumber).
+		InSource("frontmatter").
+		WithSuggestion("Check indentation and quote values that contain ':' or '#'").
+		Build()
+}
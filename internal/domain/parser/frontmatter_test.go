@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+func TestParser_ParseFrontmatter(t *testing.T) {
+	tests := []struct {
+		name                string
+		content             string
+		expectedFrontmatter *Frontmatter
+		expectedErrorLines  []int
+		expectedEndpoint    int
+	}{
+		{
+			name:    "success with basic fields",
+			content: "---\ntitle: Test API\nversion: 1.0\ndescription: Test API documentation\n---\n\n## GET /api/test\n",
+			expectedFrontmatter: &Frontmatter{
+				Title:       "Test API",
+				Version:     "1.0",
+				Description: "Test API documentation",
+				LineNumber:  1,
+			},
+			expectedEndpoint: 7,
+		},
+		{
+			name: "success with servers and metadata",
+			content: "---\ntitle: Test API\nservers:\n  - url: https://api.example.com\n    description: Production\n" +
+				"  - https://staging.example.com\nowner: platform-team\ncontacts:\n  - alice\n---\n",
+			expectedFrontmatter: &Frontmatter{
+				Title: "Test API",
+				Servers: []Server{
+					{URL: "https://api.example.com", Description: "Production"},
+					{URL: "https://staging.example.com"},
+				},
+				Metadata:   map[string]string{"owner": "platform-team", "contacts": `["alice"]`},
+				LineNumber: 1,
+			},
+		},
+		{
+			name:                "success without frontmatter",
+			content:             "# Test API\n\n## GET /api/test\n",
+			expectedFrontmatter: nil,
+			expectedEndpoint:    3,
+		},
+		{
+			name:               "error with malformed YAML points to the offending line",
+			content:            "---\ntitle: Test API\nversion: 1.0.0\ndescription: \"unterminated\n---\n\n## GET /api/test\n",
+			expectedErrorLines: []int{4},
+			expectedEndpoint:   7,
+		},
+		{
+			name:                "error with server missing url",
+			content:             "---\ntitle: Test API\nservers:\n  - description: Production\n---\n",
+			expectedFrontmatter: &Frontmatter{Title: "Test API", Servers: []Server{}, LineNumber: 1},
+			expectedErrorLines:  []int{4},
+		},
+		{
+			name:               "error with unterminated block",
+			content:            "---\ntitle: Test API\n",
+			expectedErrorLines: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := New()
+
+			doc, err := parser.Parse(tt.content)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedFrontmatter, doc.Frontmatter)
+
+			frontmatterErrors := errors.FilterByType(doc.Errors, errors.ErrorTypeFrontmatter)
+			require.Len(t, frontmatterErrors, len(tt.expectedErrorLines))
+			for i, line := range tt.expectedErrorLines {
+				assert.Equal(t, line, frontmatterErrors[i].LineNumber)
+			}
+
+			if tt.expectedEndpoint > 0 {
+				require.Len(t, doc.Endpoints, 1)
+				assert.Equal(t, tt.expectedEndpoint, doc.Endpoints[0].LineNumber)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	dateTimePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}[Tt ]\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-]\d{2}:?\d{2})$`)
+	datePattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	emailPattern    = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// isExampleLabel reports whether a label introduces an example payload rather than a schema
+func isExampleLabel(label string) bool {
+	switch label {
+	case "example", "examples", "sample", "example response", "response example", "example request",
+		"request example", "example payload", "sample response", "sample request":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseExampleNode infers a schema from an example payload and keeps the payload as its example
+func (p *Parser) parseExampleNode(node *yaml.Node, firstLine int) (*Schema, []*errors.ParseError) {
+	builder := &schemaBuilder{firstLine: firstLine, maxDepth: p.config.MaxNestingDepth}
+	schema := builder.infer(node, 1)
+	schema.Example = builder.value(node)
+	schema.Inferred = true
+	return schema, builder.errors
+}
+
+// infer builds a schema describing the shape of an example value
+func (b *schemaBuilder) infer(node *yaml.Node, depth int) *Schema {
+	schema := &Schema{LineNumber: b.lineOf(node)}
+	if b.maxDepth > 0 && depth > b.maxDepth {
+		return schema
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		schema.Type = "object"
+		schema.Properties = make(map[string]*Schema, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			schema.SetProperty(node.Content[i].Value, b.infer(node.Content[i+1], depth+1))
+		}
+	case yaml.SequenceNode:
+		schema.Type = "array"
+		for _, item := range node.Content {
+			schema.Items = mergeInferredSchemas(schema.Items, b.infer(item, depth+1))
+		}
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!int":
+			schema.Type = "integer"
+		case "!!float":
+			schema.Type = "number"
+		case "!!bool":
+			schema.Type = "boolean"
+		case "!!null":
+			// Null values say nothing about the type, leave it open
+		default:
+			schema.Type = "string"
+			schema.Format = inferStringFormat(node.Value)
+		}
+	case yaml.AliasNode:
+		return b.infer(node.Alias, depth)
+	}
+
+	return schema
+}
+
+// inferStringFormat detects well-known string formats from an example value
+func inferStringFormat(value string) string {
+	switch {
+	case dateTimePattern.MatchString(value):
+		return "date-time"
+	case datePattern.MatchString(value):
+		return "date"
+	case uuidPattern.MatchString(value):
+		return "uuid"
+	case emailPattern.MatchString(value):
+		return "email"
+	case isURI(value):
+		return "uri"
+	default:
+		return ""
+	}
+}
+
+// isURI reports whether a value is an absolute http(s) URL
+func isURI(value string) bool {
+	if !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+		return false
+	}
+	parsed, err := url.Parse(value)
+	return err == nil && parsed.Host != ""
+}
+
+// mergeInferredSchemas combines schemas inferred from different array elements
+func mergeInferredSchemas(existing, schema *Schema) *Schema {
+	switch {
+	case existing == nil:
+		return schema
+	case schema == nil || schema.Type == "":
+		return existing
+	case existing.Type == "" && len(existing.AnyOf) == 0:
+		return schema
+	}
+
+	if len(existing.AnyOf) > 0 {
+		for i, variant := range existing.AnyOf {
+			if variant.Type == schema.Type {
+				existing.AnyOf[i] = mergeInferredSchemas(variant, schema)
+				return existing
+			}
+		}
+		existing.AnyOf = append(existing.AnyOf, schema)
+		return existing
+	}
+
+	switch {
+	case existing.Type == schema.Type:
+		switch existing.Type {
+		case "object":
+			for _, name := range schema.PropertyNames() {
+				existing.SetProperty(name, mergeInferredSchemas(existing.Properties[name], schema.Properties[name]))
+			}
+		case "array":
+			existing.Items = mergeInferredSchemas(existing.Items, schema.Items)
+		case "string":
+			if existing.Format != schema.Format {
+				existing.Format = ""
+			}
+		}
+		return existing
+	case isNumericType(existing.Type) && isNumericType(schema.Type):
+		existing.Type = "number"
+		return existing
+	default:
+		return &Schema{AnyOf: []*Schema{existing, schema}, LineNumber: existing.LineNumber}
+	}
+}
+
+// isNumericType reports whether a type is integer or number
+func isNumericType(typeName string) bool {
+	return typeName == "integer" || typeName == "number"
+}
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+func TestParser_InferSchemaFromExample(t *testing.T) {
+	content := "## GET /users/{id}\n\n### Response 200\n\n```json\n" +
+		"{\n" +
+		"  \"id\": \"3f2b8c1e-9a4d-4e6b-8f0a-1c2d3e4f5a6b\",\n" +
+		"  \"email\": \"ada@example.com\",\n" +
+		"  \"age\": 36,\n" +
+		"  \"balance\": 12.5,\n" +
+		"  \"active\": true,\n" +
+		"  \"website\": \"https://example.com/ada\",\n" +
+		"  \"created_at\": \"2024-01-15T09:30:00Z\",\n" +
+		"  \"birthday\": \"1815-12-10\",\n" +
+		"  \"type\": \"admin\",\n" +
+		"  \"nickname\": null\n" +
+		"}\n" +
+		"```\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	require.Len(t, doc.Endpoints, 1)
+	assert.Empty(t, doc.Errors)
+
+	schema := doc.Endpoints[0].Responses[0].Content["application/json"]
+	require.NotNil(t, schema)
+	assert.True(t, schema.Inferred)
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, 6, schema.LineNumber)
+
+	tests := []struct {
+		property string
+		typeName string
+		format   string
+	}{
+		{"id", "string", "uuid"},
+		{"email", "string", "email"},
+		{"age", "integer", ""},
+		{"balance", "number", ""},
+		{"active", "boolean", ""},
+		{"website", "string", "uri"},
+		{"created_at", "string", "date-time"},
+		{"birthday", "string", "date"},
+		{"type", "string", ""},
+		{"nickname", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.property, func(t *testing.T) {
+			require.Contains(t, schema.Properties, tt.property)
+			assert.Equal(t, tt.typeName, schema.Properties[tt.property].Type)
+			assert.Equal(t, tt.format, schema.Properties[tt.property].Format)
+			assert.False(t, schema.Properties[tt.property].Inferred)
+		})
+	}
+
+	example, ok := schema.Example.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "ada@example.com", example["email"])
+	assert.Equal(t, 36, example["age"])
+}
+
+func TestParser_InferArrayItems(t *testing.T) {
+	content := "## GET /scores\n\n### Response 200\n\n```json\n" +
+		"[\n" +
+		"  {\"id\": 1, \"score\": 10, \"tags\": []},\n" +
+		"  {\"id\": 2, \"score\": 7.5, \"tags\": [\"new\"], \"nickname\": \"bo\"}\n" +
+		"]\n" +
+		"```\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	require.Len(t, doc.Endpoints, 1)
+
+	schema := doc.Endpoints[0].Responses[0].Content["application/json"]
+	require.NotNil(t, schema)
+	assert.Equal(t, "array", schema.Type)
+	require.NotNil(t, schema.Items)
+	assert.Equal(t, "object", schema.Items.Type)
+	assert.Equal(t, "integer", schema.Items.Properties["id"].Type)
+	assert.Equal(t, "number", schema.Items.Properties["score"].Type)
+	assert.Equal(t, "string", schema.Items.Properties["nickname"].Type)
+	require.NotNil(t, schema.Items.Properties["tags"].Items)
+	assert.Equal(t, "string", schema.Items.Properties["tags"].Items.Type)
+	assert.Len(t, schema.Example, 2)
+}
+
+func TestParser_ExampleAttachesToDeclaredSchema(t *testing.T) {
+	content := "## POST /users\n\n### Request Body\n\n" +
+		"**Example:**\n\n```json\n{\"name\": \"Ada\"}\n```\n\n" +
+		"**Schema:**\n\n```json\n{\"type\": \"object\", \"properties\": {\"name\": {\"type\": \"string\"}}}\n```\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	require.Len(t, doc.Endpoints, 1)
+	assert.Empty(t, doc.Errors)
+
+	schema := doc.Endpoints[0].RequestBody.Content["application/json"]
+	require.NotNil(t, schema)
+	assert.False(t, schema.Inferred)
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, map[string]interface{}{"name": "Ada"}, schema.Example)
+}
+
+func TestValidateDocument_InferredSchema(t *testing.T) {
+	doc, err := New().Parse("## GET /ping\n\nHealth check\n\n### Response 200\n\n```json\n{\"ok\": true}\n```\n")
+	require.NoError(t, err)
+
+	validationErrors := ValidateDocument(context.Background(), doc, false)
+	require.Len(t, validationErrors, 1)
+	assert.Equal(t, errors.SeverityInfo, validationErrors[0].Severity)
+	assert.Equal(t, 8, validationErrors[0].LineNumber)
+	assert.NotEmpty(t, validationErrors[0].Suggestion)
+}
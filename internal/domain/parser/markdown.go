@@ -0,0 +1,300 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// line represents a single source line with its 1-based line number
+type line struct {
+	number int
+	text   string
+}
+
+// blockKind identifies the kind of content block found in a section body
+type blockKind int
+
+const (
+	blockTable blockKind = iota
+	blockFence
+)
+
+// contentBlock represents a table or fenced code block together with the label that introduces it
+type contentBlock struct {
+	kind  blockKind
+	label string // normalized text of the nearest preceding "Label:" line
+	info  string // fence info string, e.g. "json"
+	lines []line // table lines, or the fence content without its delimiters
+	line  int    // line of the first table row or opening fence
+}
+
+// section represents a markdown heading and the content nested beneath it
+type section struct {
+	level    int
+	title    string
+	line     int
+	body     []line     // lines between the heading and its first child heading
+	children []*section // nested headings of a deeper level
+
+	unterminatedFence int  // line of a fence in the body that is never closed, 0 if none
+	documentTitle     bool // the heading names the document rather than a tag group
+}
+
+var headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+
+// splitLines splits content into numbered lines, starting at firstLine
+func splitLines(content string, firstLine int) []line {
+	if content == "" {
+		return []line{}
+	}
+
+	rawLines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	lines := make([]line, len(rawLines))
+	for i, text := range rawLines {
+		lines[i] = line{number: firstLine + i, text: text}
+	}
+
+	return lines
+}
+
+// parseHeading returns the level and title of an ATX heading line
+func parseHeading(text string) (int, string, bool) {
+	matches := headingPattern.FindStringSubmatch(text)
+	if matches == nil || matches[2] == "" {
+		return 0, "", false
+	}
+	return len(matches[1]), matches[2], true
+}
+
+// fenceMarker returns the fence delimiter if the line opens or closes a fenced code block
+func fenceMarker(text string) string {
+	trimmed := strings.TrimSpace(text)
+	switch {
+	case strings.HasPrefix(trimmed, "```"):
+		return "```"
+	case strings.HasPrefix(trimmed, "~~~"):
+		return "~~~"
+	default:
+		return ""
+	}
+}
+
+// buildSections groups lines into a heading tree, ignoring headings inside fenced code blocks.
+// The returned root section has level 0 and holds any content before the first heading.
+func buildSections(lines []line) *section {
+	root := &section{level: 0}
+	stack := []*section{root}
+	inFence, unterminated := fencedLines(lines)
+
+	for i, l := range lines {
+		current := stack[len(stack)-1]
+		if inFence[i] {
+			if unterminated[l.number] {
+				current.unterminatedFence = l.number
+			}
+			current.body = append(current.body, l)
+			continue
+		}
+
+		level, title, ok := parseHeading(l.text)
+		if !ok {
+			current.body = append(current.body, l)
+			continue
+		}
+
+		for len(stack) > 1 && stack[len(stack)-1].level >= level {
+			stack = stack[:len(stack)-1]
+		}
+
+		child := &section{level: level, title: title, line: l.number}
+		parent := stack[len(stack)-1]
+		parent.children = append(parent.children, child)
+		stack = append(stack, child)
+	}
+
+	return root
+}
+
+// fencedLines marks the lines that belong to fenced code blocks, including their delimiters.
+// A fence that is never closed ends before the next endpoint-level heading, so one missing
+// delimiter cannot swallow the rest of the document. The opening lines of such fences are
+// returned as the second result.
+func fencedLines(lines []line) ([]bool, map[int]bool) {
+	inFence := make([]bool, len(lines))
+	unterminated := make(map[int]bool)
+
+	for i := 0; i < len(lines); i++ {
+		marker := fenceMarker(lines[i].text)
+		if marker == "" {
+			continue
+		}
+
+		end := i + 1
+		for end < len(lines) && fenceMarker(lines[end].text) != marker {
+			end++
+		}
+		if end == len(lines) {
+			unterminated[lines[i].number] = true
+			for end = i + 1; end < len(lines); end++ {
+				if level, _, ok := parseHeading(lines[end].text); ok && level <= endpointHeadingLevel {
+					break
+				}
+			}
+			end--
+		}
+
+		for j := i; j <= end; j++ {
+			inFence[j] = true
+		}
+		i = end
+	}
+
+	return inFence, unterminated
+}
+
+// findUnterminatedFence returns the line of the first unclosed fence in a section tree, or 0
+func findUnterminatedFence(sec *section) int {
+	if sec.unterminatedFence > 0 {
+		return sec.unterminatedFence
+	}
+	for _, child := range sec.children {
+		if lineNumber := findUnterminatedFence(child); lineNumber > 0 {
+			return lineNumber
+		}
+	}
+	return 0
+}
+
+// paragraphs returns the prose paragraphs of a section body.
+// Lists, tables, block quotes and fenced code blocks are skipped.
+func paragraphs(body []line) []string {
+	var result []string
+	var current []string
+	fence := ""
+
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, strings.Join(current, " "))
+			current = nil
+		}
+	}
+
+	for _, l := range body {
+		if fence != "" {
+			if fenceMarker(l.text) == fence {
+				fence = ""
+			}
+			continue
+		}
+		if marker := fenceMarker(l.text); marker != "" {
+			flush()
+			fence = marker
+			continue
+		}
+
+		trimmed := strings.TrimSpace(l.text)
+		if trimmed == "" {
+			flush()
+			continue
+		}
+		if isStructuralLine(trimmed) {
+			flush()
+			continue
+		}
+
+		current = append(current, trimmed)
+	}
+	flush()
+
+	return result
+}
+
+// isStructuralLine reports whether a trimmed line belongs to a list, table or quote rather than prose
+func isStructuralLine(trimmed string) bool {
+	return isListItem(trimmed) || strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, ">")
+}
+
+// isListItem reports whether a trimmed line starts a markdown bullet
+func isListItem(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") || strings.HasPrefix(trimmed, "+ ")
+}
+
+// normalizeTitle lowercases a heading title and strips a trailing colon for comparison
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(title), ":"))
+}
+
+// parseLabel recognises label lines such as "Headers:", "**Schema:**" or "- Request Body:"
+func parseLabel(trimmed string) (string, bool) {
+	text := strings.TrimSpace(trimmed)
+	if isListItem(text) {
+		text = strings.TrimSpace(text[2:])
+	}
+	text = strings.TrimSpace(strings.ReplaceAll(text, "**", ""))
+	if !strings.HasSuffix(text, ":") {
+		return "", false
+	}
+
+	name := strings.TrimSpace(strings.TrimSuffix(text, ":"))
+	if name == "" || strings.Contains(name, ":") {
+		return "", false
+	}
+	return normalizeTitle(name), true
+}
+
+// scanBlocks separates the tables and fenced code blocks of a section body from its other lines.
+// Each block records the most recent label line before it, starting from initialLabel.
+func scanBlocks(body []line, initialLabel string) ([]*contentBlock, []line) {
+	var blocks []*contentBlock
+	var rest []line
+	label := initialLabel
+
+	for i := 0; i < len(body); i++ {
+		l := body[i]
+
+		if marker := fenceMarker(l.text); marker != "" {
+			block := &contentBlock{
+				kind:  blockFence,
+				label: label,
+				info:  strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(l.text), marker[:1]))),
+				line:  l.number,
+			}
+			indent := len(l.text) - len(strings.TrimLeft(l.text, " \t"))
+			for i++; i < len(body) && fenceMarker(body[i].text) != marker; i++ {
+				block.lines = append(block.lines, line{number: body[i].number, text: trimIndent(body[i].text, indent)})
+			}
+			blocks = append(blocks, block)
+			continue
+		}
+
+		if isTableLine(l.text) {
+			block := &contentBlock{kind: blockTable, label: label, line: l.number}
+			for ; i < len(body) && isTableLine(body[i].text); i++ {
+				block.lines = append(block.lines, body[i])
+			}
+			i--
+			blocks = append(blocks, block)
+			continue
+		}
+
+		trimmed := strings.TrimSpace(l.text)
+		if name, ok := parseLabel(trimmed); ok {
+			label = name
+			continue
+		}
+		if trimmed != "" {
+			label = initialLabel
+		}
+		rest = append(rest, l)
+	}
+
+	return blocks, rest
+}
+
+// trimIndent removes up to indent leading spaces from a line
+func trimIndent(text string, indent int) string {
+	for i := 0; i < indent && strings.HasPrefix(text, " "); i++ {
+		text = text[1:]
+	}
+	return text
+}
@@ -0,0 +1,34 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/internal/common"
+)
+
+var operationIDLinePattern = regexp.MustCompile("(?i)^(?:[-*+]\\s+)?(?:\\*\\*)?operation[ _-]?id\\s*(?:\\*\\*)?\\s*:\\s*(?:\\*\\*)?\\s*`?([A-Za-z_][\\w.-]*)`?$")
+
+// deriveOperationID builds an operation ID from the method and path, e.g. "GET /users/{id}" becomes "getUsersById"
+func deriveOperationID(method, path, style string) string {
+	words := []string{strings.ToLower(method)}
+	for _, segment := range strings.Split(path, "/") {
+		switch {
+		case segment == "":
+			continue
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			words = append(words, "by", strings.Trim(segment, "{}"))
+		default:
+			words = append(words, segment)
+		}
+	}
+	if len(words) == 1 {
+		words = append(words, "root")
+	}
+
+	name := strings.Join(words, " ")
+	if style == "snake" {
+		return common.ToSnakeCase(name)
+	}
+	return common.ToCamelCase(name)
+}
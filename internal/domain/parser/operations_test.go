@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+func TestDeriveOperationID(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		style    string
+		expected string
+	}{
+		{method: "GET", path: "/users", style: "camel", expected: "getUsers"},
+		{method: "GET", path: "/users/{id}", style: "camel", expected: "getUsersById"},
+		{method: "DELETE", path: "/users/{userId}/api-keys/{key_id}", style: "camel", expected: "deleteUsersByUserIdApiKeysByKeyId"},
+		{method: "POST", path: "/users/{id}/reset-password", style: "snake", expected: "post_users_by_id_reset_password"},
+		{method: "GET", path: "/", style: "camel", expected: "getRoot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, deriveOperationID(tt.method, tt.path, tt.style))
+		})
+	}
+}
+
+func TestParser_ParseOperationIDs(t *testing.T) {
+	content := "## GET /users\n\n" +
+		"**Operation ID:** listUsers\n\n" +
+		"List users.\n\n" +
+		"## GET /users/{id}\n\n" +
+		"## POST /users\n\n" +
+		"- operationId: `listUsers`\n"
+
+	doc, err := New(WithOperationIDStyle("snake")).Parse(content)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Endpoints, 3)
+	assert.Equal(t, "listUsers", doc.Endpoints[0].OperationID)
+	assert.Equal(t, "List users.", doc.Endpoints[0].Summary)
+	assert.Equal(t, "get_users_by_id", doc.Endpoints[1].OperationID)
+	assert.Equal(t, "listUsers", doc.Endpoints[2].OperationID)
+
+	require.Len(t, doc.Errors, 1)
+	assert.Equal(t, errors.ErrorTypeValidation, doc.Errors[0].Type)
+	assert.Equal(t, "duplicate operationId 'listUsers', already used by GET /users on line 1", doc.Errors[0].Message)
+	assert.Equal(t, 9, doc.Errors[0].LineNumber)
+}
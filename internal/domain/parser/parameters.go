@@ -0,0 +1,311 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+// parameterBulletShape is the expected shape of a parameter bullet, used in suggestions
+const parameterBulletShape = "- **name** (in, type, required|optional, deprecated, constraints..., x-name: value) - description"
+
+var (
+	parameterBulletPattern = regexp.MustCompile(`^[-*+]\s+\*\*([^*]+)\*\*\s*(?:\(([^)]*)\))?\s*(?:[-–—:]\s*(.*))?$`)
+	inlineExamplePattern   = regexp.MustCompile(`(?i)\s*[(,;]?\s*\bexample:\s*(.+?)\)?\s*$`)
+)
+
+// validParameterLocations lists the OpenAPI parameter locations
+var validParameterLocations = []string{"query", "path", "header", "cookie"}
+
+// parameterSectionLocation returns the default parameter location implied by a section title.
+// The second result is false when the title is not a parameter section.
+func parameterSectionLocation(title string) (string, bool) {
+	switch normalizeTitle(title) {
+	case "parameters", "request parameters":
+		return "", true
+	case "query parameters", "query":
+		return "query", true
+	case "path parameters", "path":
+		return "path", true
+	case "header parameters", "request headers", "headers":
+		return "header", true
+	case "cookie parameters", "cookies":
+		return "cookie", true
+	default:
+		return "", false
+	}
+}
+
+// parseParameterSection parses bullet-list and table parameters under a parameter section heading
+func (p *Parser) parseParameterSection(sec *section, defaultLocation string) ([]*Parameter, []*errors.ParseError) {
+	var parameters []*Parameter
+	var parseErrors []*errors.ParseError
+	var current *Parameter
+
+	blocks, rest := scanBlocks(sec.body, "")
+	for _, l := range rest {
+		trimmed := strings.TrimSpace(l.text)
+		if trimmed == "" {
+			continue
+		}
+
+		indented := l.text != strings.TrimLeft(l.text, " \t")
+		if !isListItem(trimmed) || (indented && current != nil) {
+			// Continuation lines deprecate or extend the previous parameter's description
+			if current == nil {
+				continue
+			}
+			if deprecation, errs, ok := parseDeprecatedLine(trimmed, l.number); ok {
+				current.Deprecated = deprecation
+				parseErrors = append(parseErrors, errs...)
+				continue
+			}
+			appendParameterDetail(current, strings.TrimLeft(trimmed, "-*+ "))
+			continue
+		}
+
+		parameter, err := p.parseParameterBullet(trimmed, l.number, defaultLocation)
+		if err != nil {
+			parseErrors = append(parseErrors, err)
+			current = nil
+			continue
+		}
+
+		parameters = append(parameters, parameter)
+		current = parameter
+	}
+
+	for _, parameter := range parameters {
+		parseErrors = append(parseErrors, validateParameterLocation(parameter)...)
+	}
+
+	for _, block := range blocks {
+		if block.kind != blockTable {
+			continue
+		}
+		t, err := parseTable(block.lines)
+		if err != nil {
+			parseErrors = append(parseErrors, err)
+			continue
+		}
+		tableParameters, errs := tableToParameters(t, defaultLocation)
+		parameters = append(parameters, tableParameters...)
+		parseErrors = append(parseErrors, errs...)
+	}
+
+	return parameters, parseErrors
+}
+
+// parseParameterBullet parses a single "- **name** (in, type, required) - description" bullet
+func (p *Parser) parseParameterBullet(text string, lineNumber int, defaultLocation string) (*Parameter, *errors.ParseError) {
+	matches := parameterBulletPattern.FindStringSubmatch(text)
+	if matches == nil || (matches[2] == "" && defaultLocation == "") {
+		return nil, errors.NewError(errors.ErrorTypeSyntax, fmt.Sprintf("malformed parameter definition: %s", text)).
+			AtLine(lineNumber).
+			InSource("parameter").
+			WithSuggestion("Use the form '" + parameterBulletShape + "'").
+			Build()
+	}
+
+	parameter := &Parameter{
+		Name:       strings.Trim(strings.TrimSpace(matches[1]), "`"),
+		In:         defaultLocation,
+		Type:       "string",
+		LineNumber: lineNumber,
+	}
+
+	attributes := splitAttributes(matches[2])
+	if len(attributes) > 0 && (isParameterLocation(attributes[0]) || defaultLocation == "") {
+		parameter.In = strings.ToLower(attributes[0])
+		attributes = attributes[1:]
+	}
+
+	// The type may appear anywhere among the attributes, and constraints need the schema it implies
+	typeIndex := -1
+	for i, attribute := range attributes {
+		if isTypeName(attribute) {
+			parameter.Type = strings.ToLower(attribute)
+			typeIndex = i
+			break
+		}
+	}
+	parameter.Schema = SchemaFromTypeName(parameter.Type, lineNumber)
+
+	requirementSet := false
+	for i, attribute := range attributes {
+		if i == typeIndex {
+			continue
+		}
+		if key, value, ok := parseExtensionAttribute(attribute); ok {
+			parameter.Extensions = setExtension(parameter.Extensions, key, value)
+			continue
+		}
+
+		switch lower := strings.ToLower(attribute); {
+		case lower == "required":
+			parameter.Required = true
+			requirementSet = true
+		case lower == "optional":
+			parameter.Required = false
+			requirementSet = true
+		case lower == "deprecated":
+			parameter.Deprecated = &Deprecation{LineNumber: lineNumber}
+		case applyConstraint(parameter.Schema, attribute):
+			// Constraint shorthand such as "1..100" or "default 20"
+		default:
+			return nil, errors.NewError(errors.ErrorTypeSyntax, fmt.Sprintf("unknown parameter attribute %q", attribute)).
+				AtLine(lineNumber).
+				InSource("parameter").
+				WithSuggestion("Use the form '" + parameterBulletShape + "'").
+				Build()
+		}
+	}
+
+	if !requirementSet && parameter.In == "path" {
+		parameter.Required = true
+	}
+
+	appendParameterDetail(parameter, matches[3])
+
+	return parameter, nil
+}
+
+// appendParameterDetail adds description text to a parameter, extracting any inline "example:" value
+func appendParameterDetail(parameter *Parameter, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+
+	if matches := inlineExamplePattern.FindStringSubmatchIndex(text); matches != nil {
+		parameter.Example = parseScalarValue(text[matches[2]:matches[3]], parameterType(parameter))
+		text = strings.TrimSpace(text[:matches[0]])
+	}
+
+	if text == "" {
+		return
+	}
+	if parameter.Description != "" {
+		parameter.Description += " "
+	}
+	parameter.Description += text
+}
+
+// declarePathParameters adds an implicit required string parameter for every path template variable an
+// endpoint does not declare, so the generated path always matches its parameters. Validation reports them.
+func declarePathParameters(doc *Document) {
+	for _, endpoint := range doc.Endpoints {
+		declared := make(map[string]bool)
+		for _, parameter := range endpoint.Parameters {
+			if parameter.In == "path" {
+				declared[parameter.Name] = true
+			}
+		}
+
+		for _, matches := range pathTemplatePattern.FindAllStringSubmatch(endpoint.Path, -1) {
+			name := matches[1]
+			if declared[name] {
+				continue
+			}
+			declared[name] = true
+			endpoint.Parameters = append(endpoint.Parameters, &Parameter{
+				Name:       name,
+				In:         "path",
+				Type:       "string",
+				Required:   true,
+				Schema:     &Schema{Type: "string", LineNumber: endpoint.LineNumber},
+				Implicit:   true,
+				LineNumber: endpoint.LineNumber,
+			})
+		}
+	}
+}
+
+// validateParameterLocation reports parameters whose location is not a valid OpenAPI location
+func validateParameterLocation(parameter *Parameter) []*errors.ParseError {
+	if isParameterLocation(parameter.In) {
+		return nil
+	}
+
+	return []*errors.ParseError{
+		errors.NewError(errors.ErrorTypeValidation,
+			fmt.Sprintf("invalid parameter location %q for parameter '%s'", parameter.In, parameter.Name)).
+			AtLine(parameter.LineNumber).
+			InSource("parameter").
+			WithSuggestion("Use one of: " + strings.Join(validParameterLocations, ", ")).
+			Build(),
+	}
+}
+
+// isParameterLocation reports whether value is a valid parameter location
+func isParameterLocation(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, location := range validParameterLocations {
+		if value == location {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAttributes splits a comma separated attribute list, dropping empty entries.
+// Commas inside code spans, such as in "pattern `^[a-z]{1,3}$`", do not split.
+func splitAttributes(text string) []string {
+	var attributes []string
+	start := 0
+	inCode := false
+
+	for i := 0; i <= len(text); i++ {
+		if i < len(text) && text[i] == '`' {
+			inCode = !inCode
+		}
+		if i < len(text) && (text[i] != ',' || inCode) {
+			continue
+		}
+		if part := strings.TrimSpace(text[start:i]); part != "" {
+			attributes = append(attributes, part)
+		}
+		start = i + 1
+	}
+	return attributes
+}
+
+// parameterType returns the schema type of a parameter, falling back to its declared type name
+func parameterType(parameter *Parameter) string {
+	if parameter.Schema != nil {
+		return parameter.Schema.Type
+	}
+	return parameter.Type
+}
+
+// parseScalarValue converts an inline markdown value into a typed Go value. Values of string schemas stay
+// strings, so an example such as "02134" keeps its leading zero; an empty schemaType infers the type.
+func parseScalarValue(text string, schemaType string) interface{} {
+	text = strings.Trim(strings.TrimSpace(text), "`")
+	if schemaType == "string" {
+		return strings.Trim(text, `'"`)
+	}
+
+	if i, err := strconv.Atoi(text); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return f
+	}
+	if text == "true" || text == "false" {
+		return text == "true"
+	}
+
+	var value interface{}
+	if strings.HasPrefix(text, `"`) || strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+		if err := json.Unmarshal([]byte(text), &value); err == nil {
+			return value
+		}
+	}
+
+	return strings.Trim(text, `'"`)
+}
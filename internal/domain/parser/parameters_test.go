@@ -0,0 +1,192 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+func TestParser_ParseParameters(t *testing.T) {
+	tests := []struct {
+		name               string
+		section            string
+		expectedParameters []*Parameter
+		expectedErrorTypes []errors.ErrorType
+	}{
+		{
+			name:    "success with fixture bullet",
+			section: "### Parameters\n\n- **test_param** (query, string, optional) - A test parameter\n",
+			expectedParameters: []*Parameter{
+				{
+					Name:        "test_param",
+					In:          "query",
+					Type:        "string",
+					Description: "A test parameter",
+					Schema:      &Schema{Type: "string", LineNumber: 5},
+					LineNumber:  5,
+				},
+			},
+		},
+		{
+			name: "success with inline examples and continuation lines",
+			section: "### Parameters\n\n- **id** (path, integer) - User identifier, example: 42\n" +
+				"- **X-Request-ID** (header, string, required) - Correlation ID\n  propagated to downstream services (example: `abc-123`)\n",
+			expectedParameters: []*Parameter{
+				{
+					Name:        "id",
+					In:          "path",
+					Type:        "integer",
+					Required:    true,
+					Description: "User identifier",
+					Example:     42,
+					Schema:      &Schema{Type: "integer", LineNumber: 5},
+					LineNumber:  5,
+				},
+				{
+					Name:        "X-Request-ID",
+					In:          "header",
+					Type:        "string",
+					Required:    true,
+					Description: "Correlation ID propagated to downstream services",
+					Example:     "abc-123",
+					Schema:      &Schema{Type: "string", LineNumber: 6},
+					LineNumber:  6,
+				},
+			},
+		},
+		{
+			name:    "success with location implied by section title",
+			section: "### Query Parameters\n\n- **limit** (integer, optional) - Page size\n",
+			expectedParameters: []*Parameter{
+				{
+					Name:        "limit",
+					In:          "query",
+					Type:        "integer",
+					Description: "Page size",
+					Schema:      &Schema{Type: "integer", LineNumber: 5},
+					LineNumber:  5,
+				},
+			},
+		},
+		{
+			name:    "success with array shorthand type",
+			section: "### Parameters\n\n- **ids** (query, string[]) - User IDs\n",
+			expectedParameters: []*Parameter{
+				{
+					Name:        "ids",
+					In:          "query",
+					Type:        "string[]",
+					Description: "User IDs",
+					Schema:      &Schema{Type: "array", Items: &Schema{Type: "string", LineNumber: 5}, LineNumber: 5},
+					LineNumber:  5,
+				},
+			},
+		},
+		{
+			name:    "success with type after other attributes",
+			section: "### Parameters\n\n- **page** (query, required, integer) - Page number\n",
+			expectedParameters: []*Parameter{
+				{
+					Name:        "page",
+					In:          "query",
+					Type:        "integer",
+					Required:    true,
+					Description: "Page number",
+					Schema:      &Schema{Type: "integer", LineNumber: 5},
+					LineNumber:  5,
+				},
+			},
+		},
+		{
+			name:    "success with string example keeping leading zero",
+			section: "### Parameters\n\n- **zip** (query, string) - Zip example: 02134\n",
+			expectedParameters: []*Parameter{
+				{
+					Name:        "zip",
+					In:          "query",
+					Type:        "string",
+					Description: "Zip",
+					Example:     "02134",
+					Schema:      &Schema{Type: "string", LineNumber: 5},
+					LineNumber:  5,
+				},
+			},
+		},
+		{
+			name:               "error with malformed bullet",
+			section:            "### Parameters\n\n- test_param query string\n",
+			expectedErrorTypes: []errors.ErrorType{errors.ErrorTypeSyntax},
+		},
+		{
+			name:    "error with invalid location",
+			section: "### Parameters\n\n- **invalid_param** (invalid_location, string, required) - Invalid parameter\n",
+			expectedParameters: []*Parameter{
+				{
+					Name:        "invalid_param",
+					In:          "invalid_location",
+					Type:        "string",
+					Required:    true,
+					Description: "Invalid parameter",
+					Schema:      &Schema{Type: "string", LineNumber: 5},
+					LineNumber:  5,
+				},
+			},
+			expectedErrorTypes: []errors.ErrorType{errors.ErrorTypeValidation},
+		},
+		{
+			name:               "error with unknown type",
+			section:            "### Parameters\n\n- **invalid_param** (query, invalid_type) - Invalid parameter\n",
+			expectedErrorTypes: []errors.ErrorType{errors.ErrorTypeSyntax},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := New()
+
+			doc, err := parser.Parse("## GET /api/test\n\n" + tt.section)
+			require.NoError(t, err)
+			require.Len(t, doc.Endpoints, 1)
+
+			assert.Equal(t, len(tt.expectedParameters), len(doc.Endpoints[0].Parameters))
+			for i, expected := range tt.expectedParameters {
+				if i < len(doc.Endpoints[0].Parameters) {
+					assert.Equal(t, expected, doc.Endpoints[0].Parameters[i])
+				}
+			}
+
+			require.Len(t, doc.Errors, len(tt.expectedErrorTypes))
+			for i, errorType := range tt.expectedErrorTypes {
+				assert.Equal(t, errorType, doc.Errors[i].Type)
+				assert.NotEmpty(t, doc.Errors[i].Suggestion)
+			}
+		})
+	}
+}
+
+func TestParser_DeclarePathParameters(t *testing.T) {
+	content := "## GET /users/{id}/keys/{keyId}\n\n### Parameters\n\n- **keyId** (path, integer) - Key\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	assert.Empty(t, doc.Errors)
+	require.Len(t, doc.Endpoints, 1)
+
+	parameters := doc.Endpoints[0].Parameters
+	require.Len(t, parameters, 2)
+	assert.Equal(t, "keyId", parameters[0].Name)
+	assert.Equal(t, &Parameter{
+		Name: "id", In: "path", Type: "string", Required: true, Schema: &Schema{Type: "string", LineNumber: 1},
+		Implicit: true, LineNumber: 1,
+	}, parameters[1])
+
+	// Validation reports the implicit parameter without changing the endpoint
+	validationErrors := ValidateDocument(context.Background(), doc, false)
+	require.Len(t, validationErrors, 1)
+	assert.Equal(t, errors.SeverityWarning, validationErrors[0].Severity)
+	assert.Equal(t, "path parameter 'id' is not declared, assuming a required string", validationErrors[0].Message)
+	assert.Len(t, doc.Endpoints[0].Parameters, 2)
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
 	"time"
 
@@ -25,6 +26,8 @@ type ParserConfig struct {
 	ValidationLevel      string
 	RequireExamples      bool
 	MaxNestingDepth      int
+	OperationIDStyle     string                   // "camel" or "snake", used for derived operation IDs
+	SectionParsers       map[string]SectionParser // key: normalized section heading
 }
 
 // ParserOption is a functional option for configuring the parser
@@ -80,6 +83,13 @@ func WithMaxNestingDepth(depth int) ParserOption {
 	}
 }
 
+// WithOperationIDStyle sets the naming convention of derived operation IDs
+func WithOperationIDStyle(style string) ParserOption {
+	return func(cfg *ParserConfig) {
+		cfg.OperationIDStyle = style
+	}
+}
+
 // WithInitialSliceCapacity sets the initial slice capacity for better performance
 func WithInitialSliceCapacity(capacity int) ParserOption {
 	return func(cfg *ParserConfig) {
@@ -112,54 +122,120 @@ func defaultConfig() *ParserConfig {
 		ValidationLevel:      "basic",
 		RequireExamples:      false,
 		MaxNestingDepth:      10,
+		OperationIDStyle:     "camel",
 	}
 }
 
 // Parse parses markdown content and returns a Document
 func (p *Parser) Parse(content string) (*Document, error) {
-	// Create error collector for multiple errors
-	collector := errors.NewErrorCollector(p.config.MaxRecoveryAttempts)
+	return p.ParseWithContext(context.Background(), content)
+}
+
+// ParseWithContext parses content with a context for cancellation.
+// The configured timeout is applied as a deadline. The parse phases check the context
+// between sections, so a cancelled parse stops working and returns the partial Document
+// together with the errors collected so far.
+func (p *Parser) ParseWithContext(ctx context.Context, content string) (*Document, error) {
+	if p.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.config.Timeout)
+		defer cancel()
+	}
+	startedAt := time.Now()
+
+	// Create error collector for multiple errors. Recovery is handled per section,
+	// so the collector keeps every diagnostic.
+	collector := errors.NewErrorCollector(0)
 
 	// Create document
 	doc := &Document{
-		ParsedAt: time.Now(),
+		ParsedAt: startedAt,
 		Errors:   []*errors.ParseError{},
 	}
 
-	// Parse frontmatter
-	frontmatter, remainingContent, err := p.parseFrontmatter(content)
-	if err != nil {
-		if parseErr, ok := err.(*errors.ParseError); ok {
-			collector.Add(parseErr)
-		} else {
-			collector.Add(errors.NewError(errors.ErrorTypeFrontmatter, err.Error()).Build())
-		}
-	} else {
-		doc.Frontmatter = frontmatter
+	var root *section
+	phases := []func(){
+		// Parse frontmatter and build the heading tree shared by the section parsers
+		func() {
+			frontmatter, bodyLines, frontmatterErrors := p.parseFrontmatter(splitLines(content, 1))
+			doc.Frontmatter = frontmatter
+			if frontmatter != nil {
+				doc.Extensions = maps.Clone(frontmatter.Extensions)
+			}
+			collector.AddMultiple(frontmatterErrors)
+			root = buildSections(bodyLines)
+			markDocumentTitle(root, frontmatter)
+		},
+		// Parse endpoints
+		func() {
+			endpoints, skipped, endpointErrors := p.parseEndpoints(ctx, root)
+			doc.Endpoints = endpoints
+			doc.Skipped = skipped
+			collector.AddMultiple(endpointErrors)
+		},
+		// Parse webhooks
+		func() {
+			webhooks, webhookErrors := p.parseWebhooks(ctx, root)
+			doc.Webhooks = webhooks
+			collector.AddMultiple(webhookErrors)
+		},
+		// Run the registered section parsers on document-level sections
+		func() {
+			collector.AddMultiple(p.parseDocumentSections(ctx, doc, root))
+		},
+		// Parse components
+		func() {
+			components, componentErrors := p.parseComponents(ctx, root)
+			doc.Components = components
+			collector.AddMultiple(componentErrors)
+		},
+		// Collect the tags used by endpoints
+		func() {
+			doc.Tags = collectTags(doc, root)
+		},
+		// Collect security schemes and resolve endpoint requirements
+		func() {
+			collector.AddMultiple(p.collectSecurity(doc, root))
+		},
+		// Fill endpoints from their curl examples
+		func() {
+			collector.AddMultiple(applyCurlExamples(doc))
+		},
+		// Declare the path parameters the endpoints leave implicit
+		func() {
+			declarePathParameters(doc)
+		},
+		// Resolve component references
+		func() {
+			collector.AddMultiple(resolveReferences(ctx, doc))
+		},
+		// Validate document
+		func() {
+			collector.AddMultiple(p.validateDocument(doc))
+		},
 	}
 
-	// Parse endpoints
-	endpoints, endpointErrors := p.parseEndpoints(remainingContent)
-	doc.Endpoints = endpoints
-	for _, err := range endpointErrors {
-		collector.Add(err)
+	for _, phase := range phases {
+		if ctx.Err() != nil {
+			break
+		}
+		phase()
 	}
 
-	// Parse components
-	components, componentErrors := p.parseComponents(remainingContent)
-	doc.Components = components
-	for _, err := range componentErrors {
-		collector.Add(err)
-	}
+	if err := ctx.Err(); err != nil {
+		collector.Add(errors.NewFatal(errors.ErrorTypeTimeout, "parsing stopped early: "+err.Error()).
+			WithSuggestion("The document is incomplete; split large specifications or raise the parser timeout").
+			Build())
+		doc.Errors = collector.GetAll()
 
-	// Validate document
-	validationErrors := p.validateDocument(doc)
-	for _, err := range validationErrors {
-		collector.Add(err)
+		if err == context.DeadlineExceeded {
+			return doc, errors.NewTimeoutError("parsing", time.Since(startedAt).Round(time.Millisecond).String())
+		}
+		return doc, err
 	}
 
 	// Set errors from collector
-	doc.Errors = collector.GetErrors()
+	doc.Errors = collector.GetAll()
 
 	// Return error if in strict mode and there are errors
 	if p.config.StrictMode && collector.HasErrors() {
@@ -169,60 +245,14 @@ func (p *Parser) Parse(content string) (*Document, error) {
 	return doc, nil
 }
 
-// ParseWithContext parses content with a context for cancellation
-func (p *Parser) ParseWithContext(ctx context.Context, content string) (*Document, error) {
-	// Create a channel for the result
-	resultChan := make(chan *Document, 1)
-	errorChan := make(chan error, 1)
-
-	go func() {
-		doc, err := p.Parse(content)
-		if err != nil {
-			errorChan <- err
-		} else {
-			resultChan <- doc
-		}
-	}()
-
-	select {
-	case doc := <-resultChan:
-		return doc, nil
-	case err := <-errorChan:
-		return nil, err
-	case <-ctx.Done():
-		return nil, errors.NewTimeoutError("parsing", p.config.Timeout.String())
-	}
-}
-
-// parseFrontmatter parses YAML frontmatter from the content
-func (p *Parser) parseFrontmatter(content string) (*Frontmatter, string, error) {
-	// This is a placeholder implementation
-	// In a real implementation, you would parse YAML frontmatter here
-	return nil, content, nil
-}
-
-// parseEndpoints parses endpoints from the content
-func (p *Parser) parseEndpoints(content string) ([]*Endpoint, []*errors.ParseError) {
-	// This is a placeholder implementation
-	// In a real implementation, you would parse endpoints here
-	_ = content // TODO: Implement endpoint parsing from content
-	return []*Endpoint{}, []*errors.ParseError{}
-}
-
-// parseComponents parses reusable components from the content
-func (p *Parser) parseComponents(content string) ([]*Component, []*errors.ParseError) {
-	// This is a placeholder implementation
-	// In a real implementation, you would parse components here
-	_ = content // TODO: Implement component parsing from content
-	return []*Component{}, []*errors.ParseError{}
-}
-
 // validateDocument validates the parsed document
 func (p *Parser) validateDocument(doc *Document) []*errors.ParseError {
 	var parseErrors []*errors.ParseError
 
-	// Validate endpoints
-	for _, endpoint := range doc.Endpoints {
+	// Validate endpoints, callbacks and webhooks
+	operationIDs := make(map[string]documentOperation)
+	for _, entry := range documentOperations(doc) {
+		endpoint := entry.operation
 		if !p.isValidMethod(endpoint.Method) {
 			parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeValidation,
 				fmt.Sprintf("Invalid HTTP method: %s", endpoint.Method)).
@@ -230,6 +260,21 @@ func (p *Parser) validateDocument(doc *Document) []*errors.ParseError {
 				WithSuggestion("Use one of: "+strings.Join(p.config.AllowedMethods, ", ")).
 				Build())
 		}
+
+		if endpoint.OperationID == "" {
+			continue
+		}
+		if first, ok := operationIDs[endpoint.OperationID]; ok {
+			parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeValidation,
+				fmt.Sprintf("duplicate operationId '%s', already used by %s on line %d",
+					endpoint.OperationID, first.label, first.operation.LineNumber)).
+				AtLine(endpoint.LineNumber).
+				InSource("endpoint").
+				WithSuggestion("Set a unique '**Operation ID:**' on one of the endpoints").
+				Build())
+			continue
+		}
+		operationIDs[endpoint.OperationID] = entry
 	}
 
 	return parseErrors
@@ -2,10 +2,14 @@ package parser
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
 )
 
 func TestParser_New(t *testing.T) {
@@ -209,3 +213,203 @@ func TestParser_GetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_ParseEndpoints(t *testing.T) {
+	tests := []struct {
+		name              string
+		content           string
+		expectedEndpoints []Endpoint
+		expectedErrors    int
+	}{
+		{
+			name:    "success with single endpoint",
+			content: "# Test API\n\n## GET /api/test\n\nTest endpoint description\n",
+			expectedEndpoints: []Endpoint{
+				{
+					Method:      "GET",
+					Path:        "/api/test",
+					Summary:     "Test endpoint description",
+					Description: "Test endpoint description",
+					LineNumber:  3,
+				},
+			},
+		},
+		{
+			name: "success with multiple endpoints and subsections",
+			content: "# Users\n\n## GET /users\n\nList users.\n\nSupports pagination.\n\n### Parameters\n\n" +
+				"- **limit** (query, integer, optional) - Page size\n\n## post /users\n\nCreate a user\n",
+			expectedEndpoints: []Endpoint{
+				{
+					Method:      "GET",
+					Path:        "/users",
+					Summary:     "List users.",
+					Description: "List users.\n\nSupports pagination.",
+					LineNumber:  3,
+				},
+				{
+					Method:      "POST",
+					Path:        "/users",
+					Summary:     "Create a user",
+					Description: "Create a user",
+					LineNumber:  13,
+				},
+			},
+		},
+		{
+			name:    "success ignoring headings inside code blocks",
+			content: "## GET /real\n\n```markdown\n## GET /fake\n```\n",
+			expectedEndpoints: []Endpoint{
+				{Method: "GET", Path: "/real", LineNumber: 1},
+			},
+		},
+		{
+			name:              "success ignoring non-endpoint headings",
+			content:           "# Overview\n\n## Getting started\n\nSome prose\n",
+			expectedEndpoints: []Endpoint{},
+		},
+		{
+			name:              "error with missing path",
+			content:           "## GET users\n\nList users\n",
+			expectedEndpoints: []Endpoint{},
+			expectedErrors:    1,
+		},
+		{
+			name:              "error with invalid method",
+			content:           "## INVALID_METHOD /invalid/path\n",
+			expectedEndpoints: []Endpoint{{Method: "INVALID_METHOD", Path: "/invalid/path", LineNumber: 1}},
+			expectedErrors:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := New()
+
+			doc, err := parser.Parse(tt.content)
+
+			assert.NoError(t, err)
+			assert.Len(t, doc.Errors, tt.expectedErrors)
+			assert.Len(t, doc.Endpoints, len(tt.expectedEndpoints))
+			for i, expected := range tt.expectedEndpoints {
+				if i >= len(doc.Endpoints) {
+					break
+				}
+				actual := doc.Endpoints[i]
+				assert.Equal(t, expected.Method, actual.Method)
+				assert.Equal(t, expected.Path, actual.Path)
+				assert.Equal(t, expected.Summary, actual.Summary)
+				assert.Equal(t, expected.Description, actual.Description)
+				assert.Equal(t, expected.LineNumber, actual.LineNumber)
+			}
+		})
+	}
+}
+
+func TestParser_ErrorRecovery(t *testing.T) {
+	tests := []struct {
+		name             string
+		options          []ParserOption
+		content          string
+		expectedPaths    []string
+		expectedSkipped  []string
+		expectedErrors   int
+		expectedFatal    bool
+		expectedSkipLine int
+	}{
+		{
+			name: "success resyncing after every malformed endpoint",
+			content: "## GET users\n\n## GET /users\n\n## POST users\n\n## POST /users\n\n" +
+				"## DELETE users/{id}\n\n## DELETE /users/{id}\n",
+			expectedPaths:    []string{"/users", "/users", "/users/{id}"},
+			expectedSkipped:  []string{"GET users", "POST users", "DELETE users/{id}"},
+			expectedErrors:   3,
+			expectedSkipLine: 1,
+		},
+		{
+			name:             "success skipping endpoint with unterminated fence",
+			content:          "## POST /users\n\n### Request Body\n\n```json\n{\"name\": \"Ada\"}\n\n## GET /users\n\nList users\n",
+			expectedPaths:    []string{"/users"},
+			expectedSkipped:  []string{"POST /users"},
+			expectedErrors:   1,
+			expectedSkipLine: 1,
+		},
+		{
+			name:             "error giving up after consecutive failed resyncs",
+			options:          []ParserOption{WithRecovery(true, 1)},
+			content:          "## GET /health\n\n## GET a\n\n## GET b\n\n## GET /users\n",
+			expectedPaths:    []string{"/health"},
+			expectedSkipped:  []string{"GET a", "GET b", "GET /users"},
+			expectedErrors:   3,
+			expectedFatal:    true,
+			expectedSkipLine: 3,
+		},
+		{
+			name:             "error stopping at first malformed endpoint without recovery",
+			options:          []ParserOption{WithRecovery(false, 3)},
+			content:          "## GET users\n\n## GET /users\n",
+			expectedPaths:    []string{},
+			expectedSkipped:  []string{"GET users", "GET /users"},
+			expectedErrors:   2,
+			expectedFatal:    true,
+			expectedSkipLine: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(tt.options...).Parse(tt.content)
+			assert.NoError(t, err)
+
+			paths := make([]string, 0, len(doc.Endpoints))
+			for _, endpoint := range doc.Endpoints {
+				paths = append(paths, endpoint.Path)
+			}
+			assert.Equal(t, tt.expectedPaths, paths)
+
+			skipped := make([]string, 0, len(doc.Skipped))
+			for _, s := range doc.Skipped {
+				skipped = append(skipped, s.Heading)
+				assert.NotEmpty(t, s.Reason)
+			}
+			assert.Equal(t, tt.expectedSkipped, skipped)
+			assert.Equal(t, tt.expectedSkipLine, doc.Skipped[0].LineNumber)
+
+			assert.Len(t, doc.Errors, tt.expectedErrors)
+			hasFatal := false
+			for _, parseErr := range doc.Errors {
+				hasFatal = hasFatal || parseErr.IsFatal()
+			}
+			assert.Equal(t, tt.expectedFatal, hasFatal)
+		})
+	}
+}
+
+func TestParser_ParseWithContextCancellation(t *testing.T) {
+	var content strings.Builder
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&content, "## GET /resource%d\n\nFetch resource %d\n\n### Parameters\n\n- **id** (path, integer) - Identifier\n\n", i, i)
+	}
+
+	t.Run("error with cancelled context returns partial document", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		doc, err := New().ParseWithContext(ctx, content.String())
+		assert.ErrorIs(t, err, context.Canceled)
+		require.NotNil(t, doc)
+		assert.Empty(t, doc.Endpoints)
+		require.NotEmpty(t, doc.Errors)
+		assert.Equal(t, errors.ErrorTypeTimeout, doc.Errors[len(doc.Errors)-1].Type)
+	})
+
+	t.Run("error with configured timeout stops the parse", func(t *testing.T) {
+		doc, err := New(WithTimeout(time.Nanosecond)).ParseWithContext(context.Background(), content.String())
+
+		var timeoutErr *errors.TimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "parsing", timeoutErr.Operation)
+		require.NotNil(t, doc)
+		assert.Less(t, len(doc.Endpoints), 2000)
+		assert.True(t, doc.Errors[len(doc.Errors)-1].IsFatal())
+	})
+}
@@ -0,0 +1,362 @@
+package parser
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultMediaType is used for bodies that do not declare a Content-Type
+const defaultMediaType = "application/json"
+
+var (
+	statusCodePattern     = regexp.MustCompile(`^([1-5][0-9]{2}|[1-5]XX|default)\b\s*(?:[-–—:]\s*)?(.*)$`)
+	responseBulletPattern = regexp.MustCompile(`^[-*+]\s+\*\*([1-5][0-9]{2}|[1-5]XX|default)\*\*\s*(?:[-–—:]\s*)?(.*)$`)
+	responseTitlePattern  = regexp.MustCompile(`(?i)^response(?:\s+body)?(?:\s*\(?\s*([1-5][0-9]{2}|[1-5]XX|default)\s*\)?)?(?:\s*[-–—:]\s*(.*))?$`)
+	contentTypePattern    = regexp.MustCompile("(?i)^(?:[-*+]\\s+)?(?:\\*\\*)?content-type:?(?:\\*\\*)?:?\\s*`?([\\w.+-]+/[\\w.+*-]+)`?")
+)
+
+// bodyContent holds what was found in a request or response body section
+type bodyContent struct {
+	content    map[string]*Schema // key: media type
+	mediaTypes []line             // Content-Type declarations, text holds the media type
+	headers    map[string]*Header
+	extensions map[string]interface{} // "**x-name:** value" lines
+	prose      []string
+}
+
+// mediaTypeAt returns the media type declared most recently before lineNumber.
+// Blocks that precede every declaration use the first one, or the default media type.
+func (c *bodyContent) mediaTypeAt(lineNumber int) string {
+	if len(c.mediaTypes) == 0 {
+		return defaultMediaType
+	}
+
+	mediaType := c.mediaTypes[0].text
+	for _, declared := range c.mediaTypes {
+		if declared.number > lineNumber {
+			break
+		}
+		mediaType = declared.text
+	}
+	return mediaType
+}
+
+// addSchema records a schema for a media type, merging object properties declared across blocks
+func (c *bodyContent) addSchema(mediaType string, schema *Schema) {
+	c.content[mediaType] = mergeObjectSchemas(c.content[mediaType], schema)
+}
+
+// isRequestBodySection reports whether a section title introduces the request body
+func isRequestBodySection(title string) bool {
+	_, ok := requestBodyMediaType(title)
+	return ok
+}
+
+// parseRequestBodySection parses a "### Request Body" or "### Request Body (multipart/form-data)" section
+func (p *Parser) parseRequestBodySection(sec *section) (*RequestBody, []*errors.ParseError) {
+	titleMediaType, _ := requestBodyMediaType(sec.title)
+	content, parseErrors := p.parseBodyContent(sec, "", titleMediaType)
+
+	requestBody := &RequestBody{
+		Required:   true,
+		Content:    make(map[string]*Schema),
+		Extensions: content.extensions,
+		LineNumber: sec.line,
+	}
+	if len(content.prose) > 0 {
+		requestBody.Description = strings.Join(content.prose, "\n\n")
+	}
+	for mediaType, schema := range content.content {
+		requestBody.Content[mediaType] = schema
+	}
+
+	declared := make([]string, 0, len(content.mediaTypes))
+	for _, l := range content.mediaTypes {
+		declared = append(declared, l.text)
+	}
+	parseErrors = append(parseErrors, completeRequestBody(requestBody, declared)...)
+
+	return requestBody, parseErrors
+}
+
+// parseResponsesSection parses a "### Responses" section.
+// Responses are declared either as "- **200** - description" bullets or as "#### 200 - description" headings.
+func (p *Parser) parseResponsesSection(sec *section) ([]*Response, []*errors.ParseError) {
+	var responses []*Response
+	var parseErrors []*errors.ParseError
+
+	for _, group := range groupResponseBullets(sec.body) {
+		response := newResponse(group.status, group.description, group.line)
+		content, errs := p.parseBodyContent(&section{body: group.body}, "", "")
+		parseErrors = append(parseErrors, errs...)
+		applyBodyContent(response, content)
+		responses = append(responses, response)
+	}
+
+	for _, child := range sec.children {
+		matches := statusCodePattern.FindStringSubmatch(strings.TrimSpace(child.title))
+		if matches == nil {
+			parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeSyntax,
+				"response heading must start with a status code: "+child.title).
+				AtLine(child.line).
+				InSource("response").
+				WithSuggestion("Use the form '#### 200 - Success response'").
+				Build())
+			continue
+		}
+
+		response, errs := p.parseResponseHeading(child, matches[1], matches[2])
+		parseErrors = append(parseErrors, errs...)
+		responses = append(responses, response)
+	}
+
+	return responses, parseErrors
+}
+
+// parseResponseSection parses an endpoint-level "### Response 201" section.
+// The second result is false when the title does not describe a response.
+func (p *Parser) parseResponseSection(sec *section) (*Response, []*errors.ParseError, bool) {
+	matches := responseTitlePattern.FindStringSubmatch(strings.TrimSpace(strings.TrimSuffix(sec.title, ":")))
+	if matches == nil {
+		return nil, nil, false
+	}
+
+	status := matches[1]
+	if status == "" {
+		status = "200"
+	}
+
+	response, parseErrors := p.parseResponseHeading(sec, status, matches[2])
+	return response, parseErrors, true
+}
+
+// parseResponseHeading builds a response from a heading section and its body
+func (p *Parser) parseResponseHeading(sec *section, status, description string) (*Response, []*errors.ParseError) {
+	response := newResponse(status, description, sec.line)
+	content, parseErrors := p.parseBodyContent(sec, "", "")
+	applyBodyContent(response, content)
+	return response, parseErrors
+}
+
+// parseBodyContent extracts the media types, schemas and headers described in a section body.
+// Child headings act as labels, so "##### Headers" introduces a header table.
+// A non-empty mediaType applies to the blocks before any Content-Type line.
+func (p *Parser) parseBodyContent(sec *section, label, mediaType string) (*bodyContent, []*errors.ParseError) {
+	content := &bodyContent{content: make(map[string]*Schema)}
+	var parseErrors []*errors.ParseError
+	if mediaType != "" {
+		content.mediaTypes = append(content.mediaTypes, line{number: sec.line, text: mediaType})
+	}
+
+	blocks, rest := scanBlocks(sec.body, label)
+	for _, child := range sec.children {
+		childBlocks, childRest := scanBlocks(child.body, normalizeTitle(child.title))
+		blocks = append(blocks, childBlocks...)
+		rest = append(rest, childRest...)
+	}
+
+	var prose, references []line
+	for _, l := range rest {
+		trimmed := strings.TrimSpace(l.text)
+		if matches := contentTypePattern.FindStringSubmatch(trimmed); matches != nil {
+			content.mediaTypes = append(content.mediaTypes, line{number: l.number, text: strings.ToLower(matches[1])})
+			continue
+		}
+		if schemaRefPattern.MatchString(trimmed) {
+			references = append(references, line{number: l.number, text: trimmed})
+			continue
+		}
+		if key, value, ok := parseExtensionLine(trimmed); ok {
+			content.extensions = setExtension(content.extensions, key, value)
+			continue
+		}
+		prose = append(prose, l)
+	}
+	content.prose = paragraphs(prose)
+
+	for _, reference := range references {
+		schema := schemaReference(schemaRefPattern.FindStringSubmatch(reference.text), reference.number)
+		content.addSchema(content.mediaTypeAt(reference.number), schema)
+	}
+
+	// Example payloads are applied after every schema so they attach to declared schemas
+	// regardless of their position, and only infer one when nothing was declared
+	var examples []*contentBlock
+	exampleNodes := make(map[*contentBlock]*yaml.Node)
+
+	for _, block := range blocks {
+		mediaType := content.mediaTypeAt(block.line)
+
+		switch {
+		case block.kind == blockTable:
+			t, err := parseTable(block.lines)
+			if err != nil {
+				parseErrors = append(parseErrors, err)
+				continue
+			}
+
+			if isHeadersLabel(block.label) {
+				headers, errs := tableToHeaders(t)
+				parseErrors = append(parseErrors, errs...)
+				if content.headers == nil {
+					content.headers = make(map[string]*Header)
+				}
+				for name, header := range headers {
+					content.headers[name] = header
+				}
+				continue
+			}
+
+			schema, errs := tableToSchema(t)
+			parseErrors = append(parseErrors, errs...)
+			content.addSchema(mediaType, schema)
+
+		case block.kind == blockFence && isSchemaFence(block.info):
+			node, err := parseFenceNode(block)
+			if err != nil {
+				parseErrors = append(parseErrors, err)
+				continue
+			}
+			if isExampleLabel(block.label) || !isSchemaNode(node) {
+				examples = append(examples, block)
+				exampleNodes[block] = node
+				continue
+			}
+
+			schema, errs := p.parseSchemaNode(node, block.line+1)
+			parseErrors = append(parseErrors, errs...)
+			content.addSchema(mediaType, schema)
+		}
+	}
+
+	for _, block := range examples {
+		mediaType := content.mediaTypeAt(block.line)
+		schema, errs := p.parseExampleNode(exampleNodes[block], block.line+1)
+		parseErrors = append(parseErrors, errs...)
+
+		if declared := content.content[mediaType]; declared != nil {
+			if declared.Example == nil {
+				declared.Example = schema.Example
+			}
+			continue
+		}
+		content.content[mediaType] = schema
+	}
+
+	return content, parseErrors
+}
+
+// applyBodyContent copies parsed body content onto a response.
+// Responses without any description fall back to the HTTP status text.
+func applyBodyContent(response *Response, content *bodyContent) {
+	if response.Description == "" && len(content.prose) > 0 {
+		response.Description = strings.Join(content.prose, "\n\n")
+	}
+	if response.Description == "" {
+		if code, err := strconv.Atoi(response.StatusCode); err == nil {
+			response.Description = http.StatusText(code)
+		}
+	}
+	for mediaType, schema := range content.content {
+		response.Content[mediaType] = schema
+	}
+	for name, header := range content.headers {
+		response.Headers[name] = header
+	}
+	for key, value := range content.extensions {
+		response.Extensions = setExtension(response.Extensions, key, value)
+	}
+}
+
+// newResponse creates an empty response for a status code
+func newResponse(status, description string, lineNumber int) *Response {
+	return &Response{
+		StatusCode:  status,
+		Description: strings.TrimSpace(description),
+		Headers:     make(map[string]*Header),
+		Content:     make(map[string]*Schema),
+		LineNumber:  lineNumber,
+	}
+}
+
+// responseBulletGroup is a "- **200** - description" bullet and the lines nested beneath it
+type responseBulletGroup struct {
+	status      string
+	description string
+	line        int
+	body        []line
+}
+
+// groupResponseBullets splits a responses section body into one group per status bullet
+func groupResponseBullets(body []line) []*responseBulletGroup {
+	var groups []*responseBulletGroup
+	var current *responseBulletGroup
+	fence := ""
+
+	for _, l := range body {
+		if fence == "" {
+			if matches := responseBulletPattern.FindStringSubmatch(strings.TrimSpace(l.text)); matches != nil {
+				current = &responseBulletGroup{status: matches[1], description: matches[2], line: l.number}
+				groups = append(groups, current)
+				continue
+			}
+		}
+
+		if marker := fenceMarker(l.text); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if marker == fence {
+				fence = ""
+			}
+		}
+
+		if current != nil {
+			current.body = append(current.body, l)
+		}
+	}
+
+	return groups
+}
+
+// isHeadersLabel reports whether a label introduces a header table
+func isHeadersLabel(label string) bool {
+	return label == "headers" || label == "response headers"
+}
+
+// mergeObjectSchemas combines the properties of two object schemas declared for the same body
+func mergeObjectSchemas(existing, schema *Schema) *Schema {
+	if existing == nil || schema == nil {
+		if existing == nil {
+			return schema
+		}
+		return existing
+	}
+
+	for _, name := range schema.PropertyNames() {
+		existing.SetProperty(name, schema.Properties[name])
+	}
+	for _, name := range schema.Required {
+		if !containsString(existing.Required, name) {
+			existing.Required = append(existing.Required, name)
+		}
+	}
+	for key, value := range schema.Extensions {
+		existing.Extensions = setExtension(existing.Extensions, key, value)
+	}
+	return existing
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
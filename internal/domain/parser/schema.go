@@ -0,0 +1,323 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// isSchemaFence reports whether a fence info string declares JSON or YAML content
+func isSchemaFence(info string) bool {
+	fields := strings.Fields(info)
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "json", "yaml", "yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseFenceNode parses the content of a JSON or YAML fenced block into a YAML node.
+// YAML is a superset of JSON, so both are decoded through yaml.v3 to keep node line numbers.
+func parseFenceNode(block *contentBlock) (*yaml.Node, *errors.ParseError) {
+	texts := make([]string, len(block.lines))
+	for i, l := range block.lines {
+		texts[i] = l.text
+	}
+	text := strings.Join(texts, "\n")
+	firstLine := block.line + 1
+
+	if strings.HasPrefix(block.info, "json") {
+		var value interface{}
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			lineNumber := firstLine
+			if syntaxErr, ok := err.(*json.SyntaxError); ok {
+				lineNumber += strings.Count(text[:syntaxErr.Offset], "\n")
+			}
+			return nil, errors.NewError(errors.ErrorTypeSchema, "invalid JSON: "+err.Error()).
+				AtLine(lineNumber).
+				InSource("schema").
+				WithSuggestion("Check for missing commas, quotes or closing braces").
+				Build()
+		}
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &root); err != nil {
+		parseErr := yamlError(err, firstLine-1)
+		parseErr.Type = errors.ErrorTypeSchema
+		parseErr.Source = "schema"
+		return nil, parseErr
+	}
+	if len(root.Content) == 0 {
+		return nil, errors.NewSchemaError("fenced block is empty", block.line)
+	}
+
+	return root.Content[0], nil
+}
+
+// isSchemaNode reports whether a node looks like a JSON Schema rather than an example payload.
+// Payload keys such as "type" or "items" only count when their values are schema-shaped.
+func isSchemaNode(node *yaml.Node) bool {
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch key {
+		case "$schema", "$ref":
+			if value.Kind == yaml.ScalarNode {
+				return true
+			}
+		case "type":
+			if value.Kind == yaml.ScalarNode && isSchemaTypeName(value.Value) {
+				return true
+			}
+			if value.Kind == yaml.SequenceNode && len(value.Content) > 0 && isSchemaTypeName(value.Content[0].Value) {
+				return true
+			}
+		case "properties":
+			if value.Kind == yaml.MappingNode && allMappings(value) {
+				return true
+			}
+		case "items":
+			if value.Kind == yaml.MappingNode && isSchemaNode(value) {
+				return true
+			}
+		case "allOf", "oneOf", "anyOf":
+			if value.Kind == yaml.SequenceNode && len(value.Content) > 0 && value.Content[0].Kind == yaml.MappingNode {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSchemaTypeName reports whether a value is a JSON Schema type name
+func isSchemaTypeName(value string) bool {
+	switch value {
+	case "string", "number", "integer", "boolean", "object", "array", "null":
+		return true
+	default:
+		return false
+	}
+}
+
+// allMappings reports whether every value of a mapping node is itself a mapping
+func allMappings(node *yaml.Node) bool {
+	for i := 1; i < len(node.Content); i += 2 {
+		if node.Content[i].Kind != yaml.MappingNode {
+			return false
+		}
+	}
+	return true
+}
+
+// schemaBuilder converts YAML nodes into schemas while enforcing the nesting limit
+type schemaBuilder struct {
+	firstLine int
+	maxDepth  int
+	errors    []*errors.ParseError
+}
+
+// parseSchemaNode converts a schema node parsed from a fenced block into a Schema tree
+func (p *Parser) parseSchemaNode(node *yaml.Node, firstLine int) (*Schema, []*errors.ParseError) {
+	builder := &schemaBuilder{firstLine: firstLine, maxDepth: p.config.MaxNestingDepth}
+	schema := builder.build(node, 1)
+	return schema, builder.errors
+}
+
+// lineOf returns the document line of a node
+func (b *schemaBuilder) lineOf(node *yaml.Node) int {
+	return b.firstLine + node.Line - 1
+}
+
+// build converts a mapping node into a schema, descending into nested schemas
+func (b *schemaBuilder) build(node *yaml.Node, depth int) *Schema {
+	schema := &Schema{LineNumber: b.lineOf(node)}
+
+	if b.maxDepth > 0 && depth > b.maxDepth {
+		b.errors = append(b.errors, errors.NewError(errors.ErrorTypeSchema,
+			fmt.Sprintf("schema nesting exceeds the maximum depth of %d", b.maxDepth)).
+			AtLine(b.lineOf(node)).
+			InSource("schema").
+			WithSuggestion("Move deeply nested objects into reusable components").
+			Build())
+		return schema
+	}
+
+	if node.Kind != yaml.MappingNode {
+		b.errors = append(b.errors, errors.NewSchemaError("schema must be an object", b.lineOf(node)))
+		return schema
+	}
+
+	var exclusiveMinimum, exclusiveMaximum *yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+
+		switch key {
+		case "type":
+			schema.Type = b.typeName(value)
+			schema.Nullable = schema.Nullable || allowsNull(value)
+		case "format":
+			schema.Format = value.Value
+		case "description":
+			schema.Description = value.Value
+		case "$ref":
+			schema.Ref = value.Value
+		case "required":
+			schema.Required = b.stringList(value)
+		case "enum":
+			schema.Enum = b.valueList(value)
+		case "const":
+			schema.Const = b.value(value)
+		case "default":
+			schema.Default = b.value(value)
+		case "example":
+			schema.Example = b.value(value)
+		case "minimum":
+			schema.Minimum = b.number(key, value)
+		case "maximum":
+			schema.Maximum = b.number(key, value)
+		case "exclusiveMinimum":
+			exclusiveMinimum = value
+		case "exclusiveMaximum":
+			exclusiveMaximum = value
+		case "multipleOf":
+			schema.MultipleOf = b.number(key, value)
+		case "minLength":
+			schema.MinLength = b.count(key, value)
+		case "maxLength":
+			schema.MaxLength = b.count(key, value)
+		case "pattern":
+			schema.Pattern = value.Value
+		case "minItems":
+			schema.MinItems = b.count(key, value)
+		case "maxItems":
+			schema.MaxItems = b.count(key, value)
+		case "uniqueItems":
+			schema.UniqueItems = b.flag(key, value)
+		case "minProperties":
+			schema.MinProperties = b.count(key, value)
+		case "maxProperties":
+			schema.MaxProperties = b.count(key, value)
+		case "nullable":
+			schema.Nullable = b.flag(key, value) || schema.Nullable
+		case "readOnly":
+			schema.ReadOnly = b.flag(key, value)
+		case "writeOnly":
+			schema.WriteOnly = b.flag(key, value)
+		case "deprecated":
+			schema.Deprecated = b.deprecation(value)
+		case "properties":
+			b.properties(schema, value, depth)
+		case "items":
+			schema.Items = b.build(value, depth+1)
+		case "allOf":
+			schema.AllOf = b.schemaList(value, depth)
+		case "oneOf":
+			schema.OneOf = b.schemaList(value, depth)
+		case "anyOf":
+			schema.AnyOf = b.schemaList(value, depth)
+		default:
+			if isExtensionKey(key) {
+				schema.Extensions = setExtension(schema.Extensions, key, b.value(value))
+			}
+		}
+	}
+
+	// Boolean exclusive bounds modify minimum/maximum, so they are read once those are known
+	if exclusiveMinimum != nil {
+		schema.ExclusiveMinimum = b.exclusiveBound("exclusiveMinimum", exclusiveMinimum, &schema.Minimum)
+	}
+	if exclusiveMaximum != nil {
+		schema.ExclusiveMaximum = b.exclusiveBound("exclusiveMaximum", exclusiveMaximum, &schema.Maximum)
+	}
+
+	return schema
+}
+
+// properties converts a properties mapping into named schemas
+func (b *schemaBuilder) properties(schema *Schema, node *yaml.Node, depth int) {
+	if node.Kind != yaml.MappingNode {
+		b.errors = append(b.errors, errors.NewSchemaError("'properties' must be an object", b.lineOf(node)))
+		return
+	}
+
+	schema.Properties = make(map[string]*Schema, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		schema.SetProperty(node.Content[i].Value, b.build(node.Content[i+1], depth+1))
+	}
+}
+
+// schemaList converts an allOf/oneOf/anyOf list into schemas
+func (b *schemaBuilder) schemaList(node *yaml.Node, depth int) []*Schema {
+	if node.Kind != yaml.SequenceNode {
+		b.errors = append(b.errors, errors.NewSchemaError("schema composition keywords must be lists", b.lineOf(node)))
+		return nil
+	}
+
+	schemas := make([]*Schema, 0, len(node.Content))
+	for _, item := range node.Content {
+		schemas = append(schemas, b.build(item, depth+1))
+	}
+	return schemas
+}
+
+// typeName reads a type keyword, taking the first non-null entry of a type list
+func (b *schemaBuilder) typeName(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	for _, item := range node.Content {
+		if item.Value != "null" {
+			return item.Value
+		}
+	}
+	return "null"
+}
+
+// stringList reads a list of strings such as the required keyword
+func (b *schemaBuilder) stringList(node *yaml.Node) []string {
+	if node.Kind != yaml.SequenceNode {
+		b.errors = append(b.errors, errors.NewSchemaError("'required' must be a list of property names", b.lineOf(node)))
+		return nil
+	}
+
+	values := make([]string, 0, len(node.Content))
+	for _, item := range node.Content {
+		values = append(values, item.Value)
+	}
+	return values
+}
+
+// valueList decodes a list of literal values such as the enum keyword
+func (b *schemaBuilder) valueList(node *yaml.Node) []interface{} {
+	if node.Kind != yaml.SequenceNode {
+		b.errors = append(b.errors, errors.NewSchemaError("'enum' must be a list of values", b.lineOf(node)))
+		return nil
+	}
+
+	values := make([]interface{}, 0, len(node.Content))
+	for _, item := range node.Content {
+		values = append(values, b.value(item))
+	}
+	return values
+}
+
+// value decodes a literal value node
+func (b *schemaBuilder) value(node *yaml.Node) interface{} {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		b.errors = append(b.errors, errors.NewSchemaError("cannot decode value: "+err.Error(), b.lineOf(node)))
+		return nil
+	}
+	return value
+}
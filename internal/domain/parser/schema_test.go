@@ -0,0 +1,203 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+const fixtureResponses = "## GET /api/test\n\nTest endpoint description\n\n### Responses\n\n" +
+	"- **200** - Success response\n" +
+	"  - Content-Type: application/json\n" +
+	"  - Schema:\n" +
+	"    ```json\n" +
+	"    {\n" +
+	"      \"type\": \"object\",\n" +
+	"      \"required\": [\"message\"],\n" +
+	"      \"properties\": {\n" +
+	"        \"message\": {\n" +
+	"          \"type\": \"string\"\n" +
+	"        },\n" +
+	"        \"status\": {\"type\": \"string\", \"enum\": [\"ok\", \"degraded\"]}\n" +
+	"      }\n" +
+	"    }\n" +
+	"    ```\n" +
+	"- **404** - Not found\n"
+
+func TestParser_ParseResponseSchemas(t *testing.T) {
+	doc, err := New().Parse(fixtureResponses)
+	require.NoError(t, err)
+	require.Len(t, doc.Endpoints, 1)
+	assert.Empty(t, doc.Errors)
+
+	responses := doc.Endpoints[0].Responses
+	require.Len(t, responses, 2)
+	assert.Equal(t, "200", responses[0].StatusCode)
+	assert.Equal(t, "Success response", responses[0].Description)
+	assert.Equal(t, 7, responses[0].LineNumber)
+
+	schema := responses[0].Content["application/json"]
+	require.NotNil(t, schema)
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, 11, schema.LineNumber)
+	assert.Equal(t, []string{"message"}, schema.Required)
+	require.Contains(t, schema.Properties, "message")
+	assert.Equal(t, "string", schema.Properties["message"].Type)
+	assert.Equal(t, 15, schema.Properties["message"].LineNumber)
+	assert.Equal(t, []interface{}{"ok", "degraded"}, schema.Properties["status"].Enum)
+
+	assert.Equal(t, "404", responses[1].StatusCode)
+	assert.Empty(t, responses[1].Content)
+}
+
+func TestParser_ParseYAMLSchemaBlocks(t *testing.T) {
+	content := "## POST /pets\n\n### Request Body\n\nContent-Type: application/yaml\n\n```yaml\n" +
+		"oneOf:\n" +
+		"  - $ref: '#/components/schemas/Cat'\n" +
+		"  - type: object\n" +
+		"    properties:\n" +
+		"      tags:\n" +
+		"        type: array\n" +
+		"        items:\n" +
+		"          type: [string, \"null\"]\n" +
+		"allOf:\n" +
+		"  - type: object\n" +
+		"anyOf:\n" +
+		"  - type: string\n" +
+		"```\n\n" +
+		"# Components\n\n## Schema: Cat\n\n```yaml\ntype: object\n```\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	require.Len(t, doc.Endpoints, 1)
+	assert.Empty(t, doc.Errors)
+
+	requestBody := doc.Endpoints[0].RequestBody
+	require.NotNil(t, requestBody)
+	schema := requestBody.Content["application/yaml"]
+	require.NotNil(t, schema)
+	require.Len(t, schema.OneOf, 2)
+	assert.Equal(t, "#/components/schemas/Cat", schema.OneOf[0].Ref)
+	assert.Equal(t, 9, schema.OneOf[0].LineNumber)
+	items := schema.OneOf[1].Properties["tags"].Items
+	require.NotNil(t, items)
+	assert.Equal(t, "string", items.Type)
+	assert.Equal(t, 15, items.LineNumber)
+	assert.Len(t, schema.AllOf, 1)
+	assert.Len(t, schema.AnyOf, 1)
+}
+
+func TestParser_PropertyDeclarationOrder(t *testing.T) {
+	content := "## POST /users\n\n### Request Body\n\n" +
+		"| Field | Type |\n" +
+		"| --- | --- |\n" +
+		"| zip | string |\n" +
+		"| name | string |\n\n" +
+		"```yaml\n" +
+		"type: object\n" +
+		"properties:\n" +
+		"  email: {type: string}\n" +
+		"  age: {type: integer}\n" +
+		"```\n\n" +
+		"### Response 200\n\n" +
+		"```json\n" +
+		"{\"total\": 1, \"items\": [], \"cursor\": \"abc\"}\n" +
+		"```\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	require.Len(t, doc.Endpoints, 1)
+
+	endpoint := doc.Endpoints[0]
+	require.NotNil(t, endpoint.RequestBody)
+	assert.Equal(t, []string{"zip", "name", "email", "age"}, endpoint.RequestBody.Content[defaultMediaType].PropertyNames())
+
+	require.Len(t, endpoint.Responses, 1)
+	assert.Equal(t, []string{"total", "items", "cursor"}, endpoint.Responses[0].Content[defaultMediaType].PropertyNames())
+}
+
+func TestParser_MergeRequiredProperties(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		expected []string
+	}{
+		{
+			name:     "adds new required properties",
+			yaml:     "required: [email]\n",
+			expected: []string{"name", "email"},
+		},
+		{
+			name:     "skips required properties declared twice",
+			yaml:     "required: [name, email, name]\n",
+			expected: []string{"name", "email"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "## POST /users\n\n### Request Body\n\n" +
+				"| Field | Type | Required |\n" +
+				"| --- | --- | --- |\n" +
+				"| name | string | yes |\n\n" +
+				"```yaml\n" +
+				"type: object\n" +
+				"properties:\n" +
+				"  email: {type: string}\n" +
+				tt.yaml +
+				"```\n"
+
+			doc, err := New().Parse(content)
+			require.NoError(t, err)
+			require.Len(t, doc.Endpoints, 1)
+			require.NotNil(t, doc.Endpoints[0].RequestBody)
+
+			assert.Equal(t, tt.expected, doc.Endpoints[0].RequestBody.Content[defaultMediaType].Required)
+		})
+	}
+}
+
+func TestSchema_PropertyNames(t *testing.T) {
+	schema := &Schema{Properties: map[string]*Schema{"b": {}, "a": {}}}
+	schema.SetProperty("c", &Schema{})
+	schema.SetProperty("b", &Schema{Type: "string"})
+
+	assert.Equal(t, []string{"c", "a", "b"}, schema.PropertyNames())
+	assert.Equal(t, "string", schema.Properties["b"].Type)
+}
+
+func TestParser_ParseSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       []ParserOption
+		block         string
+		expectedLines []int
+	}{
+		{
+			name:          "error with invalid JSON",
+			block:         "```json\n{\n  \"type\": \"object\"\n  \"properties\": {}\n}\n```\n",
+			expectedLines: []int{8},
+		},
+		{
+			name:          "error when nesting exceeds the maximum depth",
+			options:       []ParserOption{WithMaxNestingDepth(2)},
+			block:         "```yaml\ntype: object\nproperties:\n  a:\n    type: object\n    properties:\n      b:\n        type: string\n```\n",
+			expectedLines: []int{12},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New(tt.options...).Parse("## POST /test\n\n### Request Body\n\n" + tt.block)
+			require.NoError(t, err)
+
+			schemaErrors := errors.FilterByType(doc.Errors, errors.ErrorTypeSchema)
+			require.Len(t, schemaErrors, len(tt.expectedLines))
+			for i, line := range tt.expectedLines {
+				assert.Equal(t, line, schemaErrors[i].LineNumber)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+// SectionParser parses a custom markdown section, such as "### Rate Limits", into vendor extensions.
+// Implementations are registered per heading with WithSectionParser and take precedence over the
+// built-in section parsers.
+type SectionParser interface {
+	// ParseSection reads a section and writes structured data into the extensions of the node it
+	// belongs to: the endpoint for endpoint subsections, the document otherwise.
+	// Keys without an "x-" prefix are given one.
+	ParseSection(ctx context.Context, section RawSection, extensions map[string]interface{}) error
+}
+
+// SectionParserFunc adapts a function to the SectionParser interface
+type SectionParserFunc func(ctx context.Context, section RawSection, extensions map[string]interface{}) error
+
+// ParseSection calls f
+func (f SectionParserFunc) ParseSection(ctx context.Context, section RawSection, extensions map[string]interface{}) error {
+	return f(ctx, section, extensions)
+}
+
+// RawSection is a markdown section as handed to a SectionParser
+type RawSection struct {
+	Heading    string   // heading text without the leading "#" characters
+	Level      int      // heading level, 1 for "#"
+	Lines      []string // raw lines beneath the heading, including nested headings
+	LineNumber int      // line of the heading
+}
+
+// WithSectionParser registers a parser for sections with the given heading, e.g. "Rate Limits".
+// Headings are matched case-insensitively and ignore a trailing colon.
+func WithSectionParser(heading string, sectionParser SectionParser) ParserOption {
+	return func(cfg *ParserConfig) {
+		if cfg.SectionParsers == nil {
+			cfg.SectionParsers = make(map[string]SectionParser)
+		}
+		cfg.SectionParsers[normalizeTitle(heading)] = sectionParser
+	}
+}
+
+// sectionParser returns the registered parser for a section title, or nil
+func (p *Parser) sectionParser(title string) SectionParser {
+	if len(p.config.SectionParsers) == 0 {
+		return nil
+	}
+	return p.config.SectionParsers[normalizeTitle(title)]
+}
+
+// runSectionParser hands a section to a registered parser and merges what it writes into extensions.
+// It returns the extensions map, allocated when it was nil.
+func (p *Parser) runSectionParser(ctx context.Context, sectionParser SectionParser, sec *section,
+	extensions map[string]interface{}) (map[string]interface{}, *errors.ParseError) {
+	written := make(map[string]interface{})
+	raw := RawSection{Heading: sec.title, Level: sec.level, Lines: rawSectionLines(sec), LineNumber: sec.line}
+	if err := sectionParser.ParseSection(ctx, raw, written); err != nil {
+		return extensions, errors.NewError(errors.ErrorTypeSyntax,
+			fmt.Sprintf("section '%s' could not be parsed: %v", sec.title, err)).
+			AtLine(sec.line).
+			InSource("section").
+			Build()
+	}
+
+	if len(written) > 0 && extensions == nil {
+		extensions = make(map[string]interface{}, len(written))
+	}
+	for key, value := range written {
+		if !strings.HasPrefix(key, extensionPrefix) {
+			key = extensionPrefix + key
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+
+// parseDocumentSections runs the registered section parsers on sections outside endpoints and
+// webhooks, writing into the document extensions. Sections inside operations are handled while
+// parsing the operation.
+func (p *Parser) parseDocumentSections(ctx context.Context, doc *Document, root *section) []*errors.ParseError {
+	if len(p.config.SectionParsers) == 0 {
+		return nil
+	}
+
+	var parseErrors []*errors.ParseError
+	var walk func(sec *section)
+	walk = func(sec *section) {
+		for _, child := range sec.children {
+			if ctx.Err() != nil {
+				return
+			}
+			if sectionParser := p.sectionParser(child.title); sectionParser != nil {
+				extensions, err := p.runSectionParser(ctx, sectionParser, child, doc.Extensions)
+				doc.Extensions = extensions
+				if err != nil {
+					parseErrors = append(parseErrors, err)
+				}
+				continue
+			}
+			if isOperationSection(child) {
+				continue
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+
+	return parseErrors
+}
+
+// isOperationSection reports whether a heading declares an endpoint or a webhook
+func isOperationSection(sec *section) bool {
+	return sec.level == endpointHeadingLevel && (endpointTitlePattern.MatchString(sec.title) || isWebhookSection(sec))
+}
+
+// rawSectionLines returns the text beneath a heading, rebuilding the nested heading lines.
+// Endpoints and webhooks nested under a document-level section are not part of it.
+func rawSectionLines(sec *section) []string {
+	lines := make([]string, 0, len(sec.body))
+	for _, l := range sec.body {
+		lines = append(lines, l.text)
+	}
+	for _, child := range sec.children {
+		if isOperationSection(child) {
+			continue
+		}
+		lines = append(lines, strings.Repeat("#", child.level)+" "+child.title)
+		lines = append(lines, rawSectionLines(child)...)
+	}
+	return lines
+}
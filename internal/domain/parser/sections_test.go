@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+// listSectionParser collects "- key: value" lines into one extension named after the section
+var listSectionParser = SectionParserFunc(func(ctx context.Context, section RawSection, extensions map[string]interface{}) error {
+	values := make(map[string]interface{})
+	for _, text := range section.Lines {
+		text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "-"))
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(text, ":")
+		if !ok {
+			return fmt.Errorf("expected a 'key: value' line, got %q", text)
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	extensions[strings.ReplaceAll(strings.ToLower(section.Heading), " ", "-")] = values
+	return nil
+})
+
+func TestParser_SectionParsers(t *testing.T) {
+	content := "# SLA\n\n" +
+		"- uptime: 99.9%\n\n" +
+		"## GET /users\n\n" +
+		"### Rate Limits\n\n" +
+		"- requests: 100/minute\n\n" +
+		"#### Burst\n\n" +
+		"- burst: 20/second\n\n" +
+		"### Examples\n\n" +
+		"- note: not a curl example\n\n" +
+		"## POST /users\n\n" +
+		"### Rate Limits\n\n" +
+		"unlimited\n"
+
+	doc, err := New(
+		WithSectionParser("Rate limits:", listSectionParser),
+		WithSectionParser("SLA", listSectionParser),
+		WithSectionParser("Examples", listSectionParser),
+	).Parse(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"x-sla": map[string]interface{}{"uptime": "99.9%"}}, doc.Extensions)
+
+	require.Len(t, doc.Endpoints, 2)
+	assert.Equal(t, map[string]interface{}{
+		"x-rate-limits": map[string]interface{}{"requests": "100/minute", "burst": "20/second"},
+		"x-examples":    map[string]interface{}{"note": "not a curl example"},
+	}, doc.Endpoints[0].Extensions)
+	assert.Nil(t, doc.Endpoints[1].Extensions)
+
+	require.Len(t, doc.Errors, 1)
+	assert.Equal(t, errors.ErrorTypeSyntax, doc.Errors[0].Type)
+	assert.Equal(t, "section 'Rate Limits' could not be parsed: expected a 'key: value' line, got \"unlimited\"", doc.Errors[0].Message)
+	assert.Equal(t, 21, doc.Errors[0].LineNumber)
+}
+
+func TestRawSectionLines(t *testing.T) {
+	root := buildSections(splitLines("### Rate Limits\n\n- requests: 100\n\n#### Burst\n\n- requests: 20\n", 1))
+	require.Len(t, root.children, 1)
+
+	assert.Equal(t, []string{"", "- requests: 100", "", "#### Burst", "", "- requests: 20", ""}, rawSectionLines(root.children[0]))
+}
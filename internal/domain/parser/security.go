@@ -0,0 +1,509 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/internal/common"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	authLinePattern     = regexp.MustCompile(`(?i)^(?:[-*+]\s+)?(?:\*\*)?(?:auth|authentication|security)\s*(?:\*\*)?\s*:\s*(?:\*\*)?\s*(.+)$`)
+	keyValueLinePattern = regexp.MustCompile(`^(?:[-*+]\s+)?(?:\*\*)?([A-Za-z][A-Za-z _-]*?)\s*(?:\*\*)?\s*:\s*(?:\*\*)?\s*(.+)$`)
+	requirementPattern  = regexp.MustCompile("^`?([A-Za-z_][\\w.-]*)`?\\s*(?:[(\\[]([^)\\]]*)[)\\]])?$")
+)
+
+// securitySchemeTypes maps the scheme types accepted in markdown to an OpenAPI type and HTTP scheme
+var securitySchemeTypes = map[string][2]string{
+	"bearer":        {"http", "bearer"},
+	"jwt":           {"http", "bearer"},
+	"basic":         {"http", "basic"},
+	"http":          {"http", ""},
+	"apikey":        {"apiKey", ""},
+	"oauth2":        {"oauth2", ""},
+	"oauth":         {"oauth2", ""},
+	"openidconnect": {"openIdConnect", ""},
+	"openid":        {"openIdConnect", ""},
+	"oidc":          {"openIdConnect", ""},
+}
+
+// oauthFlowTypes maps flow names accepted in markdown to OpenAPI flow names
+var oauthFlowTypes = map[string]string{
+	"authorizationcode": "authorizationCode",
+	"clientcredentials": "clientCredentials",
+	"implicit":          "implicit",
+	"password":          "password",
+}
+
+// publicAuthValues mark an endpoint as not requiring authentication
+var publicAuthValues = []string{"none", "public", "anonymous"}
+
+// isAuthenticationSection reports whether a section title introduces the security scheme declarations
+func isAuthenticationSection(title string) bool {
+	switch normalizeTitle(title) {
+	case "authentication", "security", "security schemes", "auth":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseAuthentication parses the "# Authentication" sections.
+// Each child heading declares a scheme, and an "**Auth:**" line in the section body sets the default requirement.
+func (p *Parser) parseAuthentication(root *section) ([]*SecurityScheme, []*SecurityRequirement, []*errors.ParseError) {
+	var schemes []*SecurityScheme
+	var requirements []*SecurityRequirement
+	var parseErrors []*errors.ParseError
+
+	var walk func(sec *section)
+	walk = func(sec *section) {
+		for _, child := range sec.children {
+			if !isAuthenticationSection(child.title) {
+				walk(child)
+				continue
+			}
+
+			for _, l := range child.body {
+				if matches := authLinePattern.FindStringSubmatch(strings.TrimSpace(l.text)); matches != nil {
+					requirements = parseSecurityRequirements(matches[1], l.number)
+				}
+			}
+
+			for _, definition := range child.children {
+				if endpointTitlePattern.MatchString(definition.title) {
+					continue
+				}
+
+				scheme, errs := p.parseSecuritySchemeSection(definition)
+				parseErrors = append(parseErrors, errs...)
+				if scheme != nil {
+					schemes = append(schemes, scheme)
+				}
+			}
+		}
+	}
+	walk(root)
+
+	return schemes, requirements, parseErrors
+}
+
+// collectSecurity gathers the schemes declared in the frontmatter and "# Authentication" sections
+// and resolves the document and endpoint requirements against them
+func (p *Parser) collectSecurity(doc *Document, root *section) []*errors.ParseError {
+	schemes, requirements, parseErrors := p.parseAuthentication(root)
+
+	if doc.Frontmatter != nil {
+		doc.Security = doc.Frontmatter.Security
+		schemes = append(append([]*SecurityScheme{}, doc.Frontmatter.SecuritySchemes...), schemes...)
+	}
+	if requirements != nil {
+		doc.Security = requirements
+	}
+
+	seen := make(map[string]*SecurityScheme, len(schemes))
+	for _, scheme := range schemes {
+		if existing := seen[scheme.Name]; existing != nil {
+			parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeReference,
+				fmt.Sprintf("duplicate security scheme '%s', first declared on line %d", scheme.Name, existing.LineNumber)).
+				AtLine(scheme.LineNumber).
+				InSource("security").
+				WithSuggestion("Rename or remove one of the declarations").
+				Build())
+			continue
+		}
+		seen[scheme.Name] = scheme
+		doc.SecuritySchemes = append(doc.SecuritySchemes, scheme)
+	}
+
+	return append(parseErrors, resolveSecurity(doc)...)
+}
+
+// parseSecuritySchemeSection parses a "## bearerAuth" section declared with "- Type: bearer" lines or a YAML block
+func (p *Parser) parseSecuritySchemeSection(sec *section) (*SecurityScheme, []*errors.ParseError) {
+	name := strings.Trim(strings.TrimSpace(sec.title), "`")
+	if strings.ContainsAny(name, " \t") {
+		name = common.ToCamelCase(name)
+	}
+
+	blocks, rest := scanBlocks(sec.body, "")
+	for _, block := range blocks {
+		if block.kind != blockFence || !isSchemaFence(block.info) {
+			continue
+		}
+		node, err := parseFenceNode(block)
+		if err != nil {
+			return nil, []*errors.ParseError{err}
+		}
+		return decodeSecurityScheme(name, node, block.line, sec.line)
+	}
+
+	// Key/value lines become a flat mapping that the YAML decoder understands
+	mapping := &yaml.Node{Kind: yaml.MappingNode, Line: sec.line}
+	var prose []line
+	for _, l := range rest {
+		matches := keyValueLinePattern.FindStringSubmatch(strings.TrimSpace(l.text))
+		if matches == nil {
+			prose = append(prose, l)
+			continue
+		}
+		mapping.Content = append(mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: matches[1], Line: l.number},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: strings.Trim(strings.TrimSpace(matches[2]), "`"), Line: l.number})
+	}
+
+	scheme, parseErrors := decodeSecurityScheme(name, mapping, 0, sec.line)
+	if scheme != nil && scheme.Description == "" {
+		scheme.Description = strings.Join(paragraphs(prose), "\n\n")
+	}
+	return scheme, parseErrors
+}
+
+// decodeSecuritySchemes decodes a "securitySchemes" frontmatter mapping of names to schemes
+func decodeSecuritySchemes(node *yaml.Node, offset int) ([]*SecurityScheme, []*errors.ParseError) {
+	if node.Kind != yaml.MappingNode {
+		return nil, []*errors.ParseError{errors.NewError(errors.ErrorTypeFrontmatter, "frontmatter field 'securitySchemes' must be a mapping").
+			AtLine(offset + node.Line).
+			InSource("frontmatter").
+			WithSuggestion("Use 'securitySchemes:' followed by 'bearerAuth: bearer' style entries").
+			Build()}
+	}
+
+	var schemes []*SecurityScheme
+	var parseErrors []*errors.ParseError
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		scheme, errs := decodeSecurityScheme(key.Value, node.Content[i+1], offset, offset+key.Line)
+		parseErrors = append(parseErrors, errs...)
+		if scheme != nil {
+			schemes = append(schemes, scheme)
+		}
+	}
+	return schemes, parseErrors
+}
+
+// decodeSecurityScheme decodes a single scheme from a type name or a mapping node.
+// Mappings may nest OAuth2 flows under "flows" or describe a single flow with flat keys.
+func decodeSecurityScheme(name string, node *yaml.Node, offset, lineNumber int) (*SecurityScheme, []*errors.ParseError) {
+	scheme := &SecurityScheme{Name: name, LineNumber: lineNumber}
+	var parseErrors []*errors.ParseError
+	typeName := ""
+	flow := &OAuthFlow{}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		typeName = node.Value
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := normalizeKey(node.Content[i].Value), node.Content[i+1]
+			switch key {
+			case "type":
+				typeName = value.Value
+			case "scheme":
+				scheme.Scheme = strings.ToLower(value.Value)
+			case "bearerformat", "format":
+				scheme.BearerFormat = value.Value
+			case "in", "location":
+				scheme.In = strings.ToLower(value.Value)
+			case "name", "parameter", "parametername":
+				scheme.ParameterName = value.Value
+			case "header", "query", "cookie":
+				scheme.In, scheme.ParameterName = key, value.Value
+			case "url", "openidconnecturl":
+				scheme.OpenIDConnectURL = value.Value
+			case "description":
+				scheme.Description = value.Value
+			case "flows":
+				flows, errs := decodeOAuthFlows(value, offset)
+				scheme.Flows = append(scheme.Flows, flows...)
+				parseErrors = append(parseErrors, errs...)
+			case "flow":
+				flow.Type = oauthFlowTypes[normalizeKey(value.Value)]
+				if flow.Type == "" {
+					parseErrors = append(parseErrors, unknownFlowError(value.Value, offset+value.Line))
+				}
+			case "authorizationurl", "tokenurl", "refreshurl", "scopes":
+				decodeOAuthFlowField(flow, key, value)
+			}
+		}
+	default:
+		return nil, []*errors.ParseError{securityError(fmt.Sprintf("security scheme '%s' must be a type name or a mapping", name), lineNumber,
+			"Use 'type: bearer' or one of the other supported types")}
+	}
+
+	if flow.Type != "" {
+		scheme.Flows = append(scheme.Flows, flow)
+	}
+
+	resolved, ok := securitySchemeTypes[normalizeKey(typeName)]
+	if !ok {
+		return nil, append(parseErrors, securityError(fmt.Sprintf("unsupported security scheme type %q for '%s'", typeName, name), lineNumber,
+			"Use one of: bearer, basic, http, apiKey, oauth2, openIdConnect"))
+	}
+	scheme.Type = resolved[0]
+	if resolved[1] != "" {
+		scheme.Scheme = resolved[1]
+	}
+
+	return scheme, append(parseErrors, validateSecurityScheme(scheme)...)
+}
+
+// decodeOAuthFlows decodes a "flows" mapping of flow names to flow settings
+func decodeOAuthFlows(node *yaml.Node, offset int) ([]*OAuthFlow, []*errors.ParseError) {
+	if node.Kind != yaml.MappingNode {
+		return nil, []*errors.ParseError{securityError("'flows' must be a mapping of flow names to settings", offset+node.Line,
+			"Use 'flows:' followed by 'authorizationCode:', 'clientCredentials:', 'implicit:' or 'password:'")}
+	}
+
+	var flows []*OAuthFlow
+	var parseErrors []*errors.ParseError
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		flowType := oauthFlowTypes[normalizeKey(key.Value)]
+		if flowType == "" {
+			parseErrors = append(parseErrors, unknownFlowError(key.Value, offset+key.Line))
+			continue
+		}
+
+		flow := &OAuthFlow{Type: flowType}
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			decodeOAuthFlowField(flow, normalizeKey(value.Content[j].Value), value.Content[j+1])
+		}
+		flows = append(flows, flow)
+	}
+	return flows, parseErrors
+}
+
+// decodeOAuthFlowField sets a URL or the scopes of an OAuth2 flow
+func decodeOAuthFlowField(flow *OAuthFlow, key string, value *yaml.Node) {
+	switch key {
+	case "authorizationurl":
+		flow.AuthorizationURL = value.Value
+	case "tokenurl":
+		flow.TokenURL = value.Value
+	case "refreshurl":
+		flow.RefreshURL = value.Value
+	case "scopes":
+		flow.Scopes = decodeScopes(value)
+	}
+}
+
+// decodeScopes accepts a scope-to-description mapping, a list of scopes or a comma separated string
+func decodeScopes(node *yaml.Node) map[string]string {
+	scopes := make(map[string]string)
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			scopes[node.Content[i].Value] = node.Content[i+1].Value
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			scopes[item.Value] = ""
+		}
+	case yaml.ScalarNode:
+		for _, scope := range splitAttributes(node.Value) {
+			scopes[strings.Trim(scope, "`")] = ""
+		}
+	}
+	return scopes
+}
+
+// validateSecurityScheme checks that a scheme carries the fields its type requires
+func validateSecurityScheme(scheme *SecurityScheme) []*errors.ParseError {
+	var parseErrors []*errors.ParseError
+	fail := func(message, suggestion string) {
+		parseErrors = append(parseErrors, securityError(fmt.Sprintf("security scheme '%s' %s", scheme.Name, message), scheme.LineNumber, suggestion))
+	}
+
+	switch scheme.Type {
+	case "http":
+		if scheme.Scheme == "" {
+			fail("is missing the HTTP authentication scheme", "Add 'scheme: bearer' or 'scheme: basic'")
+		}
+	case "apiKey":
+		if scheme.In == "" {
+			scheme.In = "header"
+		}
+		if scheme.In != "header" && scheme.In != "query" && scheme.In != "cookie" {
+			fail(fmt.Sprintf("has invalid location %q", scheme.In), "Use one of: header, query, cookie")
+		}
+		if scheme.ParameterName == "" {
+			fail("is missing the API key parameter name", "Add 'name: X-API-Key'")
+		}
+	case "oauth2":
+		if len(scheme.Flows) == 0 {
+			fail("does not declare any OAuth2 flow", "Add 'flow: authorizationCode' with its URLs and scopes")
+		}
+		for _, flow := range scheme.Flows {
+			needsAuthorization := flow.Type == "authorizationCode" || flow.Type == "implicit"
+			needsToken := flow.Type != "implicit"
+			if needsAuthorization && flow.AuthorizationURL == "" {
+				fail(fmt.Sprintf("flow '%s' is missing 'authorizationUrl'", flow.Type), "Add the authorization endpoint URL")
+			}
+			if needsToken && flow.TokenURL == "" {
+				fail(fmt.Sprintf("flow '%s' is missing 'tokenUrl'", flow.Type), "Add the token endpoint URL")
+			}
+			if flow.Scopes == nil {
+				flow.Scopes = map[string]string{}
+			}
+		}
+	case "openIdConnect":
+		if scheme.OpenIDConnectURL == "" {
+			fail("is missing the OpenID Connect discovery URL", "Add 'url: https://issuer.example.com/.well-known/openid-configuration'")
+		}
+	}
+
+	return parseErrors
+}
+
+// parseSecurityRequirements parses an "**Auth:**" value such as "oauth2 (read:users), apiKey".
+// Alternatives are separated by commas or "or"; "none" yields an empty, non-nil list for public routes.
+func parseSecurityRequirements(text string, lineNumber int) []*SecurityRequirement {
+	text = strings.TrimSpace(text)
+	for _, value := range publicAuthValues {
+		if strings.EqualFold(strings.Trim(text, "`"), value) {
+			return []*SecurityRequirement{}
+		}
+	}
+
+	var requirements []*SecurityRequirement
+	for _, alternative := range splitAlternatives(text) {
+		matches := requirementPattern.FindStringSubmatch(alternative)
+		if matches == nil {
+			requirements = append(requirements, &SecurityRequirement{Scheme: alternative, LineNumber: lineNumber})
+			continue
+		}
+
+		requirement := &SecurityRequirement{Scheme: matches[1], LineNumber: lineNumber}
+		for _, scope := range strings.FieldsFunc(matches[2], func(r rune) bool { return r == ',' || r == ' ' }) {
+			requirement.Scopes = append(requirement.Scopes, strings.Trim(scope, "`"))
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements
+}
+
+// decodeSecurityRequirements decodes the frontmatter "security" value, a requirement string or a list of them
+func decodeSecurityRequirements(node *yaml.Node, offset int) []*SecurityRequirement {
+	if node.Kind != yaml.SequenceNode {
+		return parseSecurityRequirements(node.Value, offset+node.Line)
+	}
+
+	requirements := []*SecurityRequirement{}
+	for _, item := range node.Content {
+		requirements = append(requirements, parseSecurityRequirements(item.Value, offset+item.Line)...)
+	}
+	return requirements
+}
+
+// splitAlternatives splits a requirement list on commas and "or" outside parentheses and brackets
+func splitAlternatives(text string) []string {
+	var parts []string
+	depth, start := 0, 0
+	flush := func(end int) {
+		if part := strings.TrimSpace(text[start:end]); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case depth == 0 && c == ',':
+			flush(i)
+			start = i + 1
+		case depth == 0 && c == ' ' && strings.HasPrefix(strings.ToLower(text[i:]), " or "):
+			flush(i)
+			start = i + len(" or ")
+			i += len(" or ") - 1
+		}
+	}
+	flush(len(text))
+	return parts
+}
+
+// resolveSecurity checks that every requirement names a declared scheme.
+// Requirements may also use a scheme type such as "bearer" when exactly one scheme has that type.
+func resolveSecurity(doc *Document) []*errors.ParseError {
+	var parseErrors []*errors.ParseError
+
+	resolve := func(requirements []*SecurityRequirement) {
+		for _, requirement := range requirements {
+			name, err := findSecurityScheme(doc.SecuritySchemes, requirement)
+			if err != nil {
+				parseErrors = append(parseErrors, err)
+				continue
+			}
+			requirement.Scheme = name
+		}
+	}
+
+	resolve(doc.Security)
+	for _, entry := range documentOperations(doc) {
+		resolve(entry.operation.Security)
+	}
+	return parseErrors
+}
+
+// findSecurityScheme returns the name of the scheme a requirement refers to
+func findSecurityScheme(schemes []*SecurityScheme, requirement *SecurityRequirement) (string, *errors.ParseError) {
+	for _, scheme := range schemes {
+		if scheme.Name == requirement.Scheme {
+			return scheme.Name, nil
+		}
+	}
+
+	var candidates []string
+	if resolved, ok := securitySchemeTypes[normalizeKey(requirement.Scheme)]; ok {
+		for _, scheme := range schemes {
+			if scheme.Type == resolved[0] && (resolved[1] == "" || scheme.Scheme == resolved[1]) {
+				candidates = append(candidates, scheme.Name)
+			}
+		}
+	}
+
+	switch len(candidates) {
+	case 1:
+		return candidates[0], nil
+	case 0:
+		return "", errors.NewError(errors.ErrorTypeReference, fmt.Sprintf("unknown security scheme '%s'", requirement.Scheme)).
+			AtLine(requirement.LineNumber).
+			InSource("security").
+			WithSuggestion("Declare it under '# Authentication' or in the frontmatter 'securitySchemes'").
+			Build()
+	default:
+		sort.Strings(candidates)
+		return "", errors.NewError(errors.ErrorTypeReference,
+			fmt.Sprintf("ambiguous security scheme '%s' matches %s", requirement.Scheme, strings.Join(candidates, ", "))).
+			AtLine(requirement.LineNumber).
+			InSource("security").
+			WithSuggestion("Use the scheme name instead of its type").
+			Build()
+	}
+}
+
+// securityError builds a validation error for a security declaration
+func securityError(message string, lineNumber int, suggestion string) *errors.ParseError {
+	return errors.NewError(errors.ErrorTypeValidation, message).
+		AtLine(lineNumber).
+		InSource("security").
+		WithSuggestion(suggestion).
+		Build()
+}
+
+// unknownFlowError reports an unsupported OAuth2 flow name
+func unknownFlowError(flow string, lineNumber int) *errors.ParseError {
+	return securityError(fmt.Sprintf("unsupported OAuth2 flow %q", flow), lineNumber,
+		"Use one of: authorizationCode, clientCredentials, implicit, password")
+}
+
+// normalizeKey lowercases a key and drops spaces, dashes and underscores so "Token URL" matches "tokenUrl"
+func normalizeKey(key string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(key)))
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+const fixtureSecurity = "---\n" +
+	"title: Users API\n" +
+	"securitySchemes:\n" +
+	"  bearerAuth:\n" +
+	"    type: bearer\n" +
+	"    bearerFormat: JWT\n" +
+	"  partnerKey:\n" +
+	"    type: apiKey\n" +
+	"    in: query\n" +
+	"    name: api_key\n" +
+	"security: bearerAuth\n" +
+	"---\n\n" +
+	"# Authentication\n\n" +
+	"## Session Cookie\n\n" +
+	"Issued by the login endpoint.\n\n" +
+	"- Type: apiKey\n" +
+	"- Cookie: SESSION\n\n" +
+	"## oauth\n\n" +
+	"- **Type:** oauth2\n" +
+	"- **Flow:** authorizationCode\n" +
+	"- **Authorization URL:** https://auth.example.com/authorize\n" +
+	"- **Token URL:** https://auth.example.com/token\n" +
+	"- **Scopes:** read:users, write:users\n\n" +
+	"## oidc\n\n" +
+	"```yaml\n" +
+	"type: openIdConnect\n" +
+	"url: https://auth.example.com/.well-known/openid-configuration\n" +
+	"```\n\n" +
+	"## basicAuth\n\n" +
+	"- Type: basic\n\n" +
+	"# Users\n\n" +
+	"## GET /users\n\n" +
+	"List users.\n\n" +
+	"## POST /users\n\n" +
+	"**Auth:** oauth (write:users) or sessionCookie\n\n" +
+	"Create a user.\n\n" +
+	"## GET /health\n\n" +
+	"**Auth:** none\n\n" +
+	"## GET /reports\n\n" +
+	"- Auth: basic\n"
+
+func TestParser_ParseSecurity(t *testing.T) {
+	doc, err := New().Parse(fixtureSecurity)
+	require.NoError(t, err)
+	assert.Empty(t, doc.Errors)
+
+	require.Len(t, doc.SecuritySchemes, 6)
+	schemes := make(map[string]*SecurityScheme)
+	for _, scheme := range doc.SecuritySchemes {
+		schemes[scheme.Name] = scheme
+	}
+
+	assert.Equal(t, &SecurityScheme{Name: "bearerAuth", Type: "http", Scheme: "bearer", BearerFormat: "JWT", LineNumber: 4},
+		schemes["bearerAuth"])
+	assert.Equal(t, &SecurityScheme{Name: "partnerKey", Type: "apiKey", In: "query", ParameterName: "api_key", LineNumber: 7},
+		schemes["partnerKey"])
+	assert.Equal(t, &SecurityScheme{
+		Name:          "sessionCookie",
+		Type:          "apiKey",
+		In:            "cookie",
+		ParameterName: "SESSION",
+		Description:   "Issued by the login endpoint.",
+		LineNumber:    16,
+	}, schemes["sessionCookie"])
+	assert.Equal(t, "http", schemes["basicAuth"].Type)
+	assert.Equal(t, "basic", schemes["basicAuth"].Scheme)
+	assert.Equal(t, "https://auth.example.com/.well-known/openid-configuration", schemes["oidc"].OpenIDConnectURL)
+
+	require.Len(t, schemes["oauth"].Flows, 1)
+	assert.Equal(t, &OAuthFlow{
+		Type:             "authorizationCode",
+		AuthorizationURL: "https://auth.example.com/authorize",
+		TokenURL:         "https://auth.example.com/token",
+		Scopes:           map[string]string{"read:users": "", "write:users": ""},
+	}, schemes["oauth"].Flows[0])
+
+	require.Len(t, doc.Security, 1)
+	assert.Equal(t, "bearerAuth", doc.Security[0].Scheme)
+
+	require.Len(t, doc.Endpoints, 4)
+	assert.Nil(t, doc.Endpoints[0].Security)
+	assert.Equal(t, []*SecurityRequirement{
+		{Scheme: "oauth", Scopes: []string{"write:users"}, LineNumber: 50},
+		{Scheme: "sessionCookie", LineNumber: 50},
+	}, doc.Endpoints[1].Security)
+	assert.Equal(t, "Create a user.", doc.Endpoints[1].Summary)
+	assert.NotNil(t, doc.Endpoints[2].Security)
+	assert.Empty(t, doc.Endpoints[2].Security)
+	assert.Equal(t, "basicAuth", doc.Endpoints[3].Security[0].Scheme)
+}
+
+func TestParser_ParseSecurityErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errType errors.ErrorType
+		message string
+		lineNum int
+	}{
+		{
+			name:    "unknown scheme on endpoint",
+			content: "## GET /users\n\n**Auth:** bearerAuth\n",
+			errType: errors.ErrorTypeReference,
+			message: "unknown security scheme 'bearerAuth'",
+			lineNum: 3,
+		},
+		{
+			name:    "ambiguous scheme type",
+			content: "# Authentication\n\n## a\n\n- Type: bearer\n\n## b\n\n- Type: bearer\n\n## GET /users\n\n**Auth:** bearer\n",
+			errType: errors.ErrorTypeReference,
+			message: "ambiguous security scheme 'bearer' matches a, b",
+			lineNum: 13,
+		},
+		{
+			name:    "unsupported scheme type",
+			content: "# Authentication\n\n## magic\n\n- Type: kerberos\n",
+			errType: errors.ErrorTypeValidation,
+			message: "unsupported security scheme type \"kerberos\" for 'magic'",
+			lineNum: 3,
+		},
+		{
+			name:    "api key without name",
+			content: "---\nsecuritySchemes:\n  key: apiKey\n---\n",
+			errType: errors.ErrorTypeValidation,
+			message: "security scheme 'key' is missing the API key parameter name",
+			lineNum: 3,
+		},
+		{
+			name: "oauth flow without token url",
+			content: "---\nsecuritySchemes:\n  oauth:\n    type: oauth2\n    flows:\n      clientCredentials:\n" +
+				"        scopes:\n          admin: Full access\n---\n",
+			errType: errors.ErrorTypeValidation,
+			message: "security scheme 'oauth' flow 'clientCredentials' is missing 'tokenUrl'",
+			lineNum: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New().Parse(tt.content)
+			require.NoError(t, err)
+			require.Len(t, doc.Errors, 1)
+			assert.Equal(t, tt.errType, doc.Errors[0].Type)
+			assert.Equal(t, tt.message, doc.Errors[0].Message)
+			assert.Equal(t, tt.lineNum, doc.Errors[0].LineNumber)
+			assert.NotEmpty(t, doc.Errors[0].Suggestion)
+		})
+	}
+}
@@ -0,0 +1,492 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+// table represents a GitHub-flavoured markdown table
+type table struct {
+	header tableRow
+	rows   []tableRow
+	line   int
+}
+
+// tableRow represents a table row with the 1-based column at which each cell starts
+type tableRow struct {
+	cells   []string
+	columns []int
+	line    int
+	end     int // column just past the last cell
+}
+
+// tableColumn identifies the meaning of a table column
+type tableColumn string
+
+const (
+	columnName        tableColumn = "name"
+	columnIn          tableColumn = "in"
+	columnType        tableColumn = "type"
+	columnFormat      tableColumn = "format"
+	columnRequired    tableColumn = "required"
+	columnDescription tableColumn = "description"
+	columnExample     tableColumn = "example"
+	columnEnum        tableColumn = "enum"
+	columnDefault     tableColumn = "default"
+	columnConstraints tableColumn = "constraints"
+	columnDeprecated  tableColumn = "deprecated"
+	columnExtensions  tableColumn = "x-*" // any "x-name" header, one vendor extension per column
+)
+
+// columnAliases maps normalized header text to the column it describes
+var columnAliases = map[string]tableColumn{
+	"name":        columnName,
+	"parameter":   columnName,
+	"param":       columnName,
+	"field":       columnName,
+	"property":    columnName,
+	"header":      columnName,
+	"key":         columnName,
+	"in":          columnIn,
+	"location":    columnIn,
+	"where":       columnIn,
+	"type":        columnType,
+	"datatype":    columnType,
+	"schema":      columnType,
+	"format":      columnFormat,
+	"required":    columnRequired,
+	"req":         columnRequired,
+	"mandatory":   columnRequired,
+	"description": columnDescription,
+	"desc":        columnDescription,
+	"details":     columnDescription,
+	"notes":       columnDescription,
+	"example":     columnExample,
+	"sample":      columnExample,
+	"enum":        columnEnum,
+	"values":      columnEnum,
+	"allowed":     columnEnum,
+	"default":     columnDefault,
+	"constraints": columnConstraints,
+	"constraint":  columnConstraints,
+	"validation":  columnConstraints,
+	"rules":       columnConstraints,
+	"limits":      columnConstraints,
+	"deprecated":  columnDeprecated,
+	"deprecation": columnDeprecated,
+}
+
+var (
+	tableSeparatorCellPattern = regexp.MustCompile(`^:?-{1,}:?$`)
+	columnNormalizePattern    = regexp.MustCompile(`[^a-z]`)
+)
+
+// isTableLine reports whether a line is part of a markdown table
+func isTableLine(text string) bool {
+	return strings.HasPrefix(strings.TrimSpace(text), "|")
+}
+
+// parseTable parses consecutive table lines into a table.
+// The second line must be the header separator row.
+func parseTable(lines []line) (*table, *errors.ParseError) {
+	header := splitTableRow(lines[0])
+	if len(lines) < 2 || !isSeparatorRow(splitTableRow(lines[1])) {
+		return nil, errors.NewError(errors.ErrorTypeTable, "table is missing the header separator row").
+			AtLine(lines[0].number).
+			InSource("table").
+			WithSuggestion("Add a row like '| --- | --- |' below the header").
+			Build()
+	}
+
+	t := &table{header: header, line: lines[0].number}
+	for _, l := range lines[2:] {
+		t.rows = append(t.rows, splitTableRow(l))
+	}
+
+	return t, nil
+}
+
+// splitTableRow splits a table line into trimmed cells, honouring escaped pipes and code spans
+func splitTableRow(l line) tableRow {
+	row := tableRow{line: l.number}
+	text := l.text
+
+	start := strings.Index(text, "|") + 1
+	var cell strings.Builder
+	cellStart := start
+	inCode := false
+
+	flush := func(end int) {
+		raw := cell.String()
+		trimmedLeft := strings.TrimLeft(raw, " \t")
+		column := utf8.RuneCountInString(text[:cellStart]) + utf8.RuneCountInString(raw[:len(raw)-len(trimmedLeft)]) + 1
+		row.cells = append(row.cells, strings.TrimSpace(raw))
+		row.columns = append(row.columns, column)
+		row.end = utf8.RuneCountInString(text[:end]) + 1
+		cell.Reset()
+	}
+
+	for i := start; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case c == '`':
+			inCode = !inCode
+			cell.WriteByte(c)
+		case c == '|' && !inCode:
+			flush(i)
+			cellStart = i + 1
+		default:
+			cell.WriteByte(c)
+		}
+	}
+
+	// A trailing cell without a closing pipe still counts
+	if strings.TrimSpace(cell.String()) != "" {
+		flush(len(text))
+	}
+
+	return row
+}
+
+// isSeparatorRow reports whether a row is a header separator such as "| --- | :---: |"
+func isSeparatorRow(row tableRow) bool {
+	if len(row.cells) == 0 {
+		return false
+	}
+	for _, cell := range row.cells {
+		if !tableSeparatorCellPattern.MatchString(strings.ReplaceAll(cell, " ", "")) {
+			return false
+		}
+	}
+	return true
+}
+
+// mapColumns matches header cells to known columns.
+// Columns outside allowed are reported as warnings and ignored.
+func mapColumns(t *table, allowed ...tableColumn) (map[tableColumn]int, []*errors.ParseError) {
+	columns := make(map[tableColumn]int)
+	var parseErrors []*errors.ParseError
+
+	for i, cell := range t.header.cells {
+		if isExtensionKey(strings.Trim(cell, "`")) && containsColumn(allowed, columnExtensions) {
+			continue
+		}
+		normalized := columnNormalizePattern.ReplaceAllString(strings.ToLower(cell), "")
+		column, known := columnAliases[normalized]
+		if known && !containsColumn(allowed, column) {
+			known = false
+		}
+		if !known {
+			parseErrors = append(parseErrors, errors.NewWarning(errors.ErrorTypeTable,
+				fmt.Sprintf("unknown table column %q is ignored", cell)).
+				AtPosition(t.header.line, t.header.columns[i]).
+				InSource("table").
+				WithSuggestion("Use columns: "+joinColumns(allowed)).
+				Build())
+			continue
+		}
+		if _, exists := columns[column]; !exists {
+			columns[column] = i
+		}
+	}
+
+	if _, ok := columns[columnName]; !ok {
+		parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeTable, "table has no name column").
+			AtLine(t.header.line).
+			InSource("table").
+			WithSuggestion("Add a 'Name' column as the first column").
+			Build())
+	}
+
+	return columns, parseErrors
+}
+
+// checkRowWidth reports rows whose cell count differs from the header
+func checkRowWidth(t *table, row tableRow) *errors.ParseError {
+	expected := len(t.header.cells)
+	if len(row.cells) == expected {
+		return nil
+	}
+
+	column := row.end
+	message := fmt.Sprintf("table row has %d cells but the header has %d", len(row.cells), expected)
+	if len(row.cells) > expected {
+		column = row.columns[expected]
+	}
+
+	return errors.NewError(errors.ErrorTypeTable, message).
+		AtPosition(row.line, column).
+		InSource("table").
+		WithSuggestion("Make every row have the same number of '|' separated cells as the header").
+		Build()
+}
+
+// cellValue returns the value of a column in a row, or "" when absent
+func cellValue(row tableRow, columns map[tableColumn]int, column tableColumn) string {
+	index, ok := columns[column]
+	if !ok || index >= len(row.cells) {
+		return ""
+	}
+	return strings.Trim(row.cells[index], "`")
+}
+
+// typeCell reads the type column of a row, defaulting to string. Unknown types are reported and fall back to string.
+func typeCell(row tableRow, columns map[tableColumn]int) (string, *errors.ParseError) {
+	value := cellValue(row, columns, columnType)
+	if value == "" {
+		return "string", nil
+	}
+	if isTypeName(value) {
+		return strings.ToLower(value), nil
+	}
+	return "string", errors.NewError(errors.ErrorTypeTable, fmt.Sprintf("unknown type %q, assuming string", value)).
+		AtPosition(row.line, row.columns[columns[columnType]]).
+		InSource("table").
+		WithSuggestion("Use a JSON Schema type such as 'string', 'integer' or 'string[]', and put formats such as 'uuid' in the Format column").
+		Build()
+}
+
+// deprecationCell reads the deprecated column of a row; empty cells leave the row current
+func deprecationCell(row tableRow, columns map[tableColumn]int) (*Deprecation, []*errors.ParseError) {
+	value := cellValue(row, columns, columnDeprecated)
+	if value == "" {
+		return nil, nil
+	}
+	return parseDeprecation(value, row.line)
+}
+
+// extensionCells returns the vendor extensions set by the "x-name" columns of a row
+func extensionCells(t *table, row tableRow) map[string]interface{} {
+	var extensions map[string]interface{}
+	for i, cell := range t.header.cells {
+		name := strings.Trim(cell, "`")
+		if !isExtensionKey(name) || i >= len(row.cells) || row.cells[i] == "" {
+			continue
+		}
+		extensions = setExtension(extensions, name, parseScalarValue(row.cells[i], ""))
+	}
+	return extensions
+}
+
+// parseRequiredCell interprets a required column cell
+func parseRequiredCell(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "y", "true", "required", "✓", "✔", "x":
+		return true
+	default:
+		return false
+	}
+}
+
+// tableRows iterates over the data rows of a table, reporting ragged rows and rows without a name
+func tableRows(t *table, columns map[tableColumn]int, visit func(row tableRow, name string)) []*errors.ParseError {
+	var parseErrors []*errors.ParseError
+
+	for _, row := range t.rows {
+		if err := checkRowWidth(t, row); err != nil {
+			parseErrors = append(parseErrors, err)
+		}
+
+		name := cellValue(row, columns, columnName)
+		if name == "" {
+			parseErrors = append(parseErrors, errors.NewTableError("table row has an empty name cell", row.line))
+			continue
+		}
+
+		visit(row, name)
+	}
+
+	return parseErrors
+}
+
+// tableToParameters converts a parameter table into parameters
+func tableToParameters(t *table, defaultLocation string) ([]*Parameter, []*errors.ParseError) {
+	columns, parseErrors := mapColumns(t, columnName, columnIn, columnType, columnFormat,
+		columnRequired, columnDescription, columnExample, columnEnum, columnDefault, columnConstraints, columnDeprecated,
+		columnExtensions)
+	if _, ok := columns[columnName]; !ok {
+		return nil, parseErrors
+	}
+
+	var parameters []*Parameter
+	parseErrors = append(parseErrors, tableRows(t, columns, func(row tableRow, name string) {
+		typeName, err := typeCell(row, columns)
+		if err != nil {
+			parseErrors = append(parseErrors, err)
+		}
+		parameter := &Parameter{
+			Name:        name,
+			In:          strings.ToLower(cellValue(row, columns, columnIn)),
+			Type:        typeName,
+			Required:    parseRequiredCell(cellValue(row, columns, columnRequired)),
+			Description: cellValue(row, columns, columnDescription),
+			Extensions:  extensionCells(t, row),
+			LineNumber:  row.line,
+		}
+		if parameter.In == "" {
+			parameter.In = defaultLocation
+		}
+		parameter.Schema = SchemaFromTypeName(parameter.Type, row.line)
+		if parameter.In == "path" {
+			parameter.Required = true
+		}
+		if example := cellValue(row, columns, columnExample); example != "" {
+			parameter.Example = parseScalarValue(example, parameter.Schema.Type)
+		}
+		if format := cellValue(row, columns, columnFormat); format != "" {
+			parameter.Schema.Format = format
+		}
+		if enum := cellValue(row, columns, columnEnum); enum != "" {
+			parameter.Schema.Enum = parseEnumCell(enum, parameter.Schema.Type)
+		}
+		parseErrors = append(parseErrors, applyConstraintCell(parameter.Schema, row, columns)...)
+		deprecation, errs := deprecationCell(row, columns)
+		parameter.Deprecated = deprecation
+		parseErrors = append(parseErrors, errs...)
+		parameters = append(parameters, parameter)
+	})...)
+
+	for _, parameter := range parameters {
+		parseErrors = append(parseErrors, validateParameterLocation(parameter)...)
+	}
+
+	return parameters, parseErrors
+}
+
+// tableToHeaders converts a header table into response headers keyed by name
+func tableToHeaders(t *table) (map[string]*Header, []*errors.ParseError) {
+	columns, parseErrors := mapColumns(t, columnName, columnType, columnRequired, columnDescription, columnExample)
+	if _, ok := columns[columnName]; !ok {
+		return nil, parseErrors
+	}
+
+	headers := make(map[string]*Header)
+	parseErrors = append(parseErrors, tableRows(t, columns, func(row tableRow, name string) {
+		header := &Header{
+			Type:        strings.ToLower(cellValue(row, columns, columnType)),
+			Description: cellValue(row, columns, columnDescription),
+		}
+		if header.Type == "" {
+			header.Type = "string"
+		}
+		if example := cellValue(row, columns, columnExample); example != "" {
+			header.Example = parseScalarValue(example, header.Type)
+		}
+		headers[name] = header
+	})...)
+
+	return headers, parseErrors
+}
+
+// tableToSchema converts a property table into an object schema
+func tableToSchema(t *table) (*Schema, []*errors.ParseError) {
+	columns, parseErrors := mapColumns(t, columnName, columnType, columnFormat, columnRequired,
+		columnDescription, columnExample, columnEnum, columnDefault, columnConstraints, columnDeprecated, columnExtensions)
+	if _, ok := columns[columnName]; !ok {
+		return nil, parseErrors
+	}
+
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+		LineNumber: t.line,
+	}
+
+	parseErrors = append(parseErrors, tableRows(t, columns, func(row tableRow, name string) {
+		typeName, err := typeCell(row, columns)
+		if err != nil {
+			parseErrors = append(parseErrors, err)
+		}
+		property := SchemaFromTypeName(typeName, row.line)
+		property.Description = cellValue(row, columns, columnDescription)
+		if format := cellValue(row, columns, columnFormat); format != "" {
+			property.Format = format
+		}
+		if example := cellValue(row, columns, columnExample); example != "" {
+			property.Example = parseScalarValue(example, property.Type)
+		}
+		property.Enum = parseEnumCell(cellValue(row, columns, columnEnum), property.Type)
+		property.Extensions = extensionCells(t, row)
+		deprecation, errs := deprecationCell(row, columns)
+		property.Deprecated = deprecation
+		parseErrors = append(parseErrors, errs...)
+		parseErrors = append(parseErrors, applyConstraintCell(property, row, columns)...)
+
+		schema.SetProperty(name, property)
+		if parseRequiredCell(cellValue(row, columns, columnRequired)) {
+			schema.Required = append(schema.Required, name)
+		}
+	})...)
+
+	return schema, parseErrors
+}
+
+// SchemaFromTypeName builds a schema from a shorthand type such as "string", "string[]", "array[integer]" or "file"
+func SchemaFromTypeName(typeName string, lineNumber int) *Schema {
+	typeName = strings.ToLower(strings.TrimSpace(typeName))
+
+	switch {
+	case typeName == "":
+		return &Schema{Type: "string", LineNumber: lineNumber}
+	case strings.HasSuffix(typeName, "[]"):
+		return &Schema{Type: "array", Items: SchemaFromTypeName(strings.TrimSuffix(typeName, "[]"), lineNumber), LineNumber: lineNumber}
+	case strings.HasPrefix(typeName, "array[") && strings.HasSuffix(typeName, "]"):
+		return &Schema{Type: "array", Items: SchemaFromTypeName(typeName[len("array["):len(typeName)-1], lineNumber), LineNumber: lineNumber}
+	default:
+		if schema, ok := fileSchema(typeName, lineNumber); ok {
+			return schema
+		}
+		return &Schema{Type: typeName, LineNumber: lineNumber}
+	}
+}
+
+// isTypeName reports whether text is a type shorthand that SchemaFromTypeName understands
+func isTypeName(text string) bool {
+	typeName := strings.ToLower(strings.TrimSpace(text))
+	switch {
+	case strings.HasSuffix(typeName, "[]"):
+		return isTypeName(strings.TrimSuffix(typeName, "[]"))
+	case strings.HasPrefix(typeName, "array[") && strings.HasSuffix(typeName, "]"):
+		return isTypeName(typeName[len("array[") : len(typeName)-1])
+	default:
+		return fileTypePattern.MatchString(typeName) || (typeName != "null" && isSchemaTypeName(typeName))
+	}
+}
+
+// parseEnumCell splits an enum cell such as "active, inactive" into values of the schema type
+func parseEnumCell(value string, schemaType string) []interface{} {
+	var values []interface{}
+	for _, part := range splitAttributes(value) {
+		values = append(values, parseScalarValue(part, schemaType))
+	}
+	return values
+}
+
+// containsColumn reports whether columns contains column
+func containsColumn(columns []tableColumn, column tableColumn) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// joinColumns formats allowed columns for suggestions
+func joinColumns(columns []tableColumn) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		if column == columnExtensions {
+			names[i] = string(column)
+			continue
+		}
+		names[i] = strings.ToUpper(string(column[:1])) + string(column[1:])
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,183 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+func TestParser_ParseParameterTables(t *testing.T) {
+	content := "## GET /users/{id}\n\n### Parameters\n\n" +
+		"| Parameter | Location | Data Type | Required | Description | Example |\n" +
+		"|-----------|:--------:|-----------|----------|-------------|---------|\n" +
+		"| id | path | integer | yes | User ID | 42 |\n" +
+		"| `fields` | query | string | no | Fields to include \\| comma separated | name |\n" +
+		"| tags | query | array[string] | no | Labels | |\n" +
+		"| zip | query | string | no | Zip code | 02134 |\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	require.Len(t, doc.Endpoints, 1)
+	assert.Empty(t, doc.Errors)
+
+	parameters := doc.Endpoints[0].Parameters
+	require.Len(t, parameters, 4)
+	assert.Equal(t, &Parameter{
+		Name: "id", In: "path", Type: "integer", Required: true, Description: "User ID", Example: 42,
+		Schema: &Schema{Type: "integer", LineNumber: 7}, LineNumber: 7,
+	}, parameters[0])
+	assert.Equal(t, &Parameter{
+		Name: "fields", In: "query", Type: "string", Description: "Fields to include | comma separated", Example: "name",
+		Schema: &Schema{Type: "string", LineNumber: 8}, LineNumber: 8,
+	}, parameters[1])
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string", LineNumber: 9}, LineNumber: 9}, parameters[2].Schema)
+	assert.Equal(t, "02134", parameters[3].Example)
+}
+
+func TestParser_ParseParameterTableArrayTypes(t *testing.T) {
+	content := "## GET /users\n\n### Parameters\n\n" +
+		"| Name | In | Type |\n| --- | --- | --- |\n" +
+		"| ids | query | integer[] |\n| names | query | array[string] |\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	require.Len(t, doc.Endpoints, 1)
+	assert.Empty(t, doc.Errors)
+
+	parameters := doc.Endpoints[0].Parameters
+	require.Len(t, parameters, 2)
+	assert.Equal(t, "array", parameters[0].Schema.Type)
+	assert.Equal(t, "integer", parameters[0].Schema.Items.Type)
+	assert.Equal(t, "array", parameters[1].Schema.Type)
+	assert.Equal(t, "string", parameters[1].Schema.Items.Type)
+}
+
+func TestParser_ParseHeaderAndBodyTables(t *testing.T) {
+	content := "## POST /users\n\n### Headers\n\n" +
+		"| Name | Type | Required | Description |\n| --- | --- | --- | --- |\n" +
+		"| X-Request-ID | string | yes | Correlation ID |\n\n" +
+		"### Request Body\n\nThe user to create.\n\n" +
+		"| Field | Type | Required | Description |\n| --- | --- | --- | --- |\n" +
+		"| name | string | yes | Display name |\n| tags | string[] | no | Labels |\n\n" +
+		"### Responses\n\n#### 201 - Created\n\nHeaders:\n\n" +
+		"| Header | Type | Description |\n| --- | --- | --- |\n| Location | string | URL of the new user |\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	require.Len(t, doc.Endpoints, 1)
+	assert.Empty(t, doc.Errors)
+
+	endpoint := doc.Endpoints[0]
+	require.Len(t, endpoint.Parameters, 1)
+	assert.Equal(t, "header", endpoint.Parameters[0].In)
+	assert.True(t, endpoint.Parameters[0].Required)
+
+	require.NotNil(t, endpoint.RequestBody)
+	assert.Equal(t, "The user to create.", endpoint.RequestBody.Description)
+	body := endpoint.RequestBody.Content["application/json"]
+	require.NotNil(t, body)
+	assert.Equal(t, "object", body.Type)
+	assert.Equal(t, []string{"name"}, body.Required)
+	assert.Equal(t, "string", body.Properties["name"].Type)
+	assert.Equal(t, "array", body.Properties["tags"].Type)
+	assert.Equal(t, "string", body.Properties["tags"].Items.Type)
+
+	require.Len(t, endpoint.Responses, 1)
+	response := endpoint.Responses[0]
+	assert.Equal(t, "201", response.StatusCode)
+	assert.Equal(t, "Created", response.Description)
+	require.Contains(t, response.Headers, "Location")
+	assert.Equal(t, "URL of the new user", response.Headers["Location"].Description)
+	assert.Empty(t, response.Content)
+}
+
+func TestParser_ParseTableUnknownTypes(t *testing.T) {
+	content := "## POST /users\n\n### Parameters\n\n" +
+		"| Name | In | Type |\n| --- | --- | --- |\n" +
+		"| id | query | uuid |\n\n" +
+		"### Request Body\n\n" +
+		"| Field | Type |\n| --- | --- |\n" +
+		"| name | Stringg |\n| tags | string[] |\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	require.Len(t, doc.Endpoints, 1)
+
+	var messages []string
+	for _, parseErr := range doc.Errors {
+		messages = append(messages, parseErr.Message)
+	}
+	assert.Equal(t, []string{`unknown type "uuid", assuming string`, `unknown type "Stringg", assuming string`}, messages)
+
+	parameter := doc.Endpoints[0].Parameters[0]
+	assert.Equal(t, "string", parameter.Type)
+	assert.Equal(t, &Schema{Type: "string", LineNumber: 7}, parameter.Schema)
+
+	schema := doc.Endpoints[0].RequestBody.Content[defaultMediaType]
+	require.NotNil(t, schema)
+	assert.Equal(t, "string", schema.Properties["name"].Type)
+	assert.Equal(t, "array", schema.Properties["tags"].Type)
+}
+
+func TestParser_ParseTableErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		table          string
+		expectedErrors []*errors.ParseError
+	}{
+		{
+			name:  "error with ragged row",
+			table: "| Name | In | Type |\n| --- | --- | --- |\n| limit | query |\n",
+			expectedErrors: []*errors.ParseError{
+				{Type: errors.ErrorTypeTable, Severity: errors.SeverityError, LineNumber: 7, Column: 17},
+			},
+		},
+		{
+			name:  "error with extra cells",
+			table: "| Name | In |\n| --- | --- |\n| limit | query | extra |\n",
+			expectedErrors: []*errors.ParseError{
+				{Type: errors.ErrorTypeTable, Severity: errors.SeverityError, LineNumber: 7, Column: 19},
+			},
+		},
+		{
+			name:  "warning with unknown column",
+			table: "| Name | In | Colour |\n| --- | --- | --- |\n| limit | query | red |\n",
+			expectedErrors: []*errors.ParseError{
+				{Type: errors.ErrorTypeTable, Severity: errors.SeverityWarning, LineNumber: 5, Column: 15},
+			},
+		},
+		{
+			name:  "error with unknown type",
+			table: "| Name | In | Type |\n| --- | --- | --- |\n| id | path | uuid |\n",
+			expectedErrors: []*errors.ParseError{
+				{Type: errors.ErrorTypeTable, Severity: errors.SeverityError, LineNumber: 7, Column: 15},
+			},
+		},
+		{
+			name:  "error with missing separator",
+			table: "| Name | In |\n| limit | query |\n",
+			expectedErrors: []*errors.ParseError{
+				{Type: errors.ErrorTypeTable, Severity: errors.SeverityError, LineNumber: 5},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := New()
+			endpoint, parseErrors := parser.parseEndpoint(context.Background(), buildSections(splitLines("## GET /users\n\n### Parameters\n\n"+tt.table, 1)).children[0])
+			require.NotNil(t, endpoint)
+
+			require.Len(t, parseErrors, len(tt.expectedErrors))
+			for i, expected := range tt.expectedErrors {
+				assert.Equal(t, expected.Type, parseErrors[i].Type)
+				assert.Equal(t, expected.Severity, parseErrors[i].Severity)
+				assert.Equal(t, expected.LineNumber, parseErrors[i].LineNumber)
+				assert.Equal(t, expected.Column, parseErrors[i].Column)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+var tagsLinePattern = regexp.MustCompile(`(?i)^(?:[-*+]\s+)?(?:\*\*)?tags?\s*(?:\*\*)?\s*:\s*(?:\*\*)?\s*(.+)$`)
+
+// isTagGroupSection reports whether a heading groups the endpoints beneath it under a tag.
+// Every top-level heading does, except the document title and the components and authentication sections.
+func isTagGroupSection(sec *section) bool {
+	return sec.level == 1 &&
+		!sec.documentTitle &&
+		!endpointTitlePattern.MatchString(sec.title) &&
+		!isComponentsSection(sec.title) &&
+		!isAuthenticationSection(sec.title)
+}
+
+// markDocumentTitle marks the top-level headings that repeat the frontmatter title. Such a heading names the
+// document, so the endpoints beneath it stay untagged.
+func markDocumentTitle(root *section, frontmatter *Frontmatter) {
+	if frontmatter == nil || strings.TrimSpace(frontmatter.Title) == "" {
+		return
+	}
+	for _, sec := range root.children {
+		if sec.level == 1 && strings.EqualFold(tagName(sec.title), strings.TrimSpace(frontmatter.Title)) {
+			sec.documentTitle = true
+		}
+	}
+}
+
+// tagName returns the tag named by a group heading
+func tagName(title string) string {
+	return strings.Trim(strings.TrimSpace(title), "`")
+}
+
+// parseTagList parses the value of a "**Tags:** users, admin" line
+func parseTagList(text string) []string {
+	tags := []string{}
+	seen := make(map[string]bool)
+
+	for _, field := range strings.Split(text, ",") {
+		tag := tagName(field)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// collectTags builds the document tags in the order endpoints and webhooks first use them.
+// Tags named after a group heading take their description from the prose beneath it.
+func collectTags(doc *Document, root *section) []*Tag {
+	groups := make(map[string]*Tag)
+	for _, sec := range root.children {
+		if !isTagGroupSection(sec) {
+			continue
+		}
+		name := tagName(sec.title)
+		if _, ok := groups[name]; ok {
+			continue
+		}
+		groups[name] = &Tag{
+			Name:        name,
+			Description: strings.Join(paragraphs(sec.body), "\n\n"),
+			LineNumber:  sec.line,
+		}
+	}
+
+	tags := []*Tag{}
+	seen := make(map[string]bool)
+	for _, entry := range documentOperations(doc) {
+		endpoint := entry.operation
+		for _, name := range endpoint.Tags {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			tag := groups[name]
+			if tag == nil {
+				tag = &Tag{Name: name, LineNumber: endpoint.LineNumber}
+			}
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ParseTags(t *testing.T) {
+	content := "# Users\n\n" +
+		"Operations on user accounts.\n\n" +
+		"Requires an account.\n\n" +
+		"## GET /users\n\n" +
+		"List users.\n\n" +
+		"## POST /users\n\n" +
+		"**Tags:** admin, `Users`, admin\n\n" +
+		"Create a user.\n\n" +
+		"# Components\n\n" +
+		"## Schema: User\n\n" +
+		"```json\n{\"type\": \"object\"}\n```\n\n" +
+		"## GET /health\n\n" +
+		"# Orders\n\n" +
+		"### Notes\n\n" +
+		"## GET /orders\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+	assert.Empty(t, doc.Errors)
+
+	require.Len(t, doc.Endpoints, 4)
+	assert.Equal(t, []string{"Users"}, doc.Endpoints[0].Tags)
+	assert.Equal(t, []string{"admin", "Users"}, doc.Endpoints[1].Tags)
+	assert.Equal(t, "Create a user.", doc.Endpoints[1].Summary)
+	assert.Nil(t, doc.Endpoints[2].Tags)
+	assert.Equal(t, []string{"Orders"}, doc.Endpoints[3].Tags)
+
+	assert.Equal(t, []*Tag{
+		{Name: "Users", Description: "Operations on user accounts.\n\nRequires an account.", LineNumber: 1},
+		{Name: "admin", LineNumber: 11},
+		{Name: "Orders", LineNumber: 27},
+	}, doc.Tags)
+}
+
+func TestParser_DocumentTitleIsNotATag(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		firstTags []string
+		tags      []string
+	}{
+		{
+			name:    "heading matching the frontmatter title",
+			content: "---\ntitle: Test API\n---\n\n# Test API\n\n## GET /api/test\n\n# Users\n\n## GET /users\n",
+			tags:    []string{"Users"},
+		},
+		{
+			name:    "heading matching the frontmatter title in another case",
+			content: "---\ntitle: Test API\n---\n\n# test api\n\n## GET /api/test\n\n## GET /users\n",
+		},
+		{
+			name:      "group heading differing from the frontmatter title",
+			content:   "---\ntitle: Test API\n---\n\n# Users\n\n## GET /api/test\n\n## GET /users\n",
+			firstTags: []string{"Users"},
+			tags:      []string{"Users"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := New().Parse(tt.content)
+			require.NoError(t, err)
+			require.Len(t, doc.Endpoints, 2)
+			assert.Equal(t, tt.firstTags, doc.Endpoints[0].Tags)
+
+			var names []string
+			for _, tag := range doc.Tags {
+				names = append(names, tag.Name)
+			}
+			assert.Equal(t, tt.tags, names)
+		})
+	}
+}
@@ -2,11 +2,17 @@ package parser
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/sukhera/APIWeaver/pkg/errors"
 )
 
+// pathTemplatePattern matches the "{id}" variables of a path template
+var pathTemplatePattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
 // Visitor pattern for traversing and manipulating AST nodes
 // This allows for separation of algorithms from the data structure
 
@@ -20,6 +26,9 @@ type Visitor interface {
 	VisitResponse(ctx context.Context, response *Response) error
 	VisitSchema(ctx context.Context, schema *Schema) error
 	VisitComponent(ctx context.Context, component *Component) error
+	VisitSecurityScheme(ctx context.Context, scheme *SecurityScheme) error
+	VisitWebhook(ctx context.Context, webhook *Webhook) error
+	VisitCallback(ctx context.Context, callback *Callback) error
 }
 
 // Visitable interface for AST nodes that can accept visitors
@@ -42,6 +51,13 @@ func (d *Document) Accept(ctx context.Context, visitor Visitor) error {
 		}
 	}
 
+	// Visit all security schemes
+	for _, scheme := range d.SecuritySchemes {
+		if err := scheme.Accept(ctx, visitor); err != nil {
+			return err
+		}
+	}
+
 	// Visit all endpoints
 	for _, endpoint := range d.Endpoints {
 		if err := endpoint.Accept(ctx, visitor); err != nil {
@@ -49,6 +65,13 @@ func (d *Document) Accept(ctx context.Context, visitor Visitor) error {
 		}
 	}
 
+	// Visit all webhooks
+	for _, webhook := range d.Webhooks {
+		if err := webhook.Accept(ctx, visitor); err != nil {
+			return err
+		}
+	}
+
 	// Visit all components
 	for _, component := range d.Components {
 		if err := component.Accept(ctx, visitor); err != nil {
@@ -70,6 +93,11 @@ func (e *Endpoint) Accept(ctx context.Context, visitor Visitor) error {
 		return err
 	}
 
+	return e.acceptOperation(ctx, visitor)
+}
+
+// acceptOperation visits the parts of an endpoint, webhook or callback operation
+func (e *Endpoint) acceptOperation(ctx context.Context, visitor Visitor) error {
 	// Visit all parameters
 	for _, param := range e.Parameters {
 		if err := param.Accept(ctx, visitor); err != nil {
@@ -91,6 +119,37 @@ func (e *Endpoint) Accept(ctx context.Context, visitor Visitor) error {
 		}
 	}
 
+	// Visit all callbacks
+	for _, callback := range e.Callbacks {
+		if err := callback.Accept(ctx, visitor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Webhook Accept method
+func (w *Webhook) Accept(ctx context.Context, visitor Visitor) error {
+	if err := visitor.VisitWebhook(ctx, w); err != nil {
+		return err
+	}
+
+	if w.Operation != nil {
+		return w.Operation.acceptOperation(ctx, visitor)
+	}
+	return nil
+}
+
+// Callback Accept method
+func (c *Callback) Accept(ctx context.Context, visitor Visitor) error {
+	if err := visitor.VisitCallback(ctx, c); err != nil {
+		return err
+	}
+
+	if c.Operation != nil {
+		return c.Operation.acceptOperation(ctx, visitor)
+	}
 	return nil
 }
 
@@ -149,8 +208,8 @@ func (s *Schema) Accept(ctx context.Context, visitor Visitor) error {
 	}
 
 	// Visit all properties
-	for _, prop := range s.Properties {
-		if err := prop.Accept(ctx, visitor); err != nil {
+	for _, name := range s.PropertyNames() {
+		if err := s.Properties[name].Accept(ctx, visitor); err != nil {
 			return err
 		}
 	}
@@ -202,6 +261,11 @@ func (c *Component) Accept(ctx context.Context, visitor Visitor) error {
 	return nil
 }
 
+// SecurityScheme Accept method
+func (s *SecurityScheme) Accept(ctx context.Context, visitor Visitor) error {
+	return visitor.VisitSecurityScheme(ctx, s)
+}
+
 // Base visitor implementation that provides default no-op behavior
 type BaseVisitor struct{}
 
@@ -217,28 +281,50 @@ func (v *BaseVisitor) VisitRequestBody(ctx context.Context, requestBody *Request
 func (v *BaseVisitor) VisitResponse(ctx context.Context, response *Response) error    { return nil }
 func (v *BaseVisitor) VisitSchema(ctx context.Context, schema *Schema) error          { return nil }
 func (v *BaseVisitor) VisitComponent(ctx context.Context, component *Component) error { return nil }
+func (v *BaseVisitor) VisitSecurityScheme(ctx context.Context, scheme *SecurityScheme) error {
+	return nil
+}
+func (v *BaseVisitor) VisitWebhook(ctx context.Context, webhook *Webhook) error    { return nil }
+func (v *BaseVisitor) VisitCallback(ctx context.Context, callback *Callback) error { return nil }
 
 // Concrete visitor implementations
 
 // ValidationVisitor performs comprehensive validation during traversal
 type ValidationVisitor struct {
 	BaseVisitor
-	errors      []*errors.ParseError
-	strictMode  bool
-	currentPath string
+	errors           []*errors.ParseError
+	strictMode       bool
+	requireEndpoints bool
+	currentPath      string
+}
+
+// ValidationOption configures a ValidationVisitor
+type ValidationOption func(*ValidationVisitor)
+
+// WithEndpointsRequired sets whether a document without endpoints or webhooks is an error.
+// Exports that only need the components turn it off.
+func WithEndpointsRequired(required bool) ValidationOption {
+	return func(v *ValidationVisitor) {
+		v.requireEndpoints = required
+	}
 }
 
-func NewValidationVisitor(strictMode bool) *ValidationVisitor {
-	return &ValidationVisitor{
-		errors:     []*errors.ParseError{},
-		strictMode: strictMode,
+func NewValidationVisitor(strictMode bool, opts ...ValidationOption) *ValidationVisitor {
+	v := &ValidationVisitor{
+		errors:           []*errors.ParseError{},
+		strictMode:       strictMode,
+		requireEndpoints: true,
+	}
+	for _, opt := range opts {
+		opt(v)
 	}
+	return v
 }
 
 func (v *ValidationVisitor) VisitDocument(ctx context.Context, doc *Document) error {
 	v.currentPath = "document"
 
-	if len(doc.Endpoints) == 0 {
+	if v.requireEndpoints && len(doc.Endpoints) == 0 && len(doc.Webhooks) == 0 {
 		v.addError("error", "document must contain at least one endpoint", 0)
 	}
 
@@ -252,30 +338,34 @@ func (v *ValidationVisitor) VisitDocument(ctx context.Context, doc *Document) er
 		paths[key] = endpoint
 	}
 
+	// Check for templated paths that only differ in their variable names, e.g. /users/{id} and /users/{userId}
+	templates := make(map[string]*Endpoint)
+	for _, endpoint := range doc.Endpoints {
+		key := endpoint.Method + " " + pathTemplatePattern.ReplaceAllString(endpoint.Path, "{}")
+		if existing := templates[key]; existing != nil && existing.Path != endpoint.Path {
+			v.addError("error", fmt.Sprintf("path %s %s collides with %s on line %d",
+				endpoint.Method, endpoint.Path, existing.Path, existing.LineNumber), endpoint.LineNumber)
+			continue
+		}
+		if templates[key] == nil {
+			templates[key] = endpoint
+		}
+	}
+
 	return nil
 }
 
 func (v *ValidationVisitor) VisitEndpoint(ctx context.Context, endpoint *Endpoint) error {
 	v.currentPath = "endpoint[" + endpoint.Method + " " + endpoint.Path + "]"
 
-	// Validate HTTP method
-	validMethods := []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
-	valid := false
-	for _, method := range validMethods {
-		if endpoint.Method == method {
-			valid = true
-			break
-		}
-	}
-	if !valid {
-		v.addError("error", "invalid HTTP method: "+endpoint.Method, endpoint.LineNumber)
-	}
-
 	// Validate path
 	if !strings.HasPrefix(endpoint.Path, "/") {
 		v.addError("error", "path must start with /", endpoint.LineNumber)
 	}
 
+	v.validatePathParameters(endpoint)
+	v.validateSunset("endpoint "+endpoint.Method+" "+endpoint.Path, endpoint.Deprecated)
+
 	// Check for required descriptions in strict mode
 	if v.strictMode && endpoint.Description == "" {
 		v.addError("warning", "endpoint description is recommended", endpoint.LineNumber)
@@ -284,6 +374,63 @@ func (v *ValidationVisitor) VisitEndpoint(ctx context.Context, endpoint *Endpoin
 	return nil
 }
 
+func (v *ValidationVisitor) VisitWebhook(ctx context.Context, webhook *Webhook) error {
+	v.currentPath = "webhook[" + webhook.Name + "]"
+
+	if webhook.Operation != nil {
+		v.validateSunset("webhook "+webhook.Name, webhook.Operation.Deprecated)
+	}
+
+	return nil
+}
+
+func (v *ValidationVisitor) VisitCallback(ctx context.Context, callback *Callback) error {
+	v.currentPath += ".callback[" + callback.Name + "]"
+
+	if callback.Expression == "" {
+		v.addError("error", "callback must have a URL expression", callback.LineNumber)
+	}
+	if callback.Operation != nil {
+		v.validateSunset("callback "+callback.Name, callback.Operation.Deprecated)
+	}
+
+	return nil
+}
+
+// validatePathParameters cross-checks the path template variables against the path parameters, reporting
+// undeclared variables, implicit parameters added for them and declared parameters missing from the template
+func (v *ValidationVisitor) validatePathParameters(endpoint *Endpoint) {
+	variables := make(map[string]bool)
+	for _, matches := range pathTemplatePattern.FindAllStringSubmatch(endpoint.Path, -1) {
+		variables[matches[1]] = true
+	}
+
+	declared := make(map[string]bool)
+	for _, parameter := range endpoint.Parameters {
+		if parameter.In != "path" {
+			continue
+		}
+		declared[parameter.Name] = true
+		if parameter.Implicit {
+			v.addError("warning", fmt.Sprintf("path parameter '%s' is not declared, assuming a required string", parameter.Name),
+				parameter.LineNumber)
+		}
+		if !variables[parameter.Name] {
+			v.addError("error", fmt.Sprintf("path parameter '%s' does not appear in the path %s", parameter.Name, endpoint.Path),
+				parameter.LineNumber)
+		}
+	}
+
+	for _, matches := range pathTemplatePattern.FindAllStringSubmatch(endpoint.Path, -1) {
+		name := matches[1]
+		if declared[name] {
+			continue
+		}
+		declared[name] = true
+		v.addError("error", fmt.Sprintf("path parameter '%s' is not declared", name), endpoint.LineNumber)
+	}
+}
+
 func (v *ValidationVisitor) VisitParameter(ctx context.Context, parameter *Parameter) error {
 	v.currentPath += ".parameter[" + parameter.Name + "]"
 
@@ -305,6 +452,8 @@ func (v *ValidationVisitor) VisitParameter(ctx context.Context, parameter *Param
 		v.addError("error", "path parameters must be required", parameter.LineNumber)
 	}
 
+	v.validateSunset("parameter '"+parameter.Name+"'", parameter.Deprecated)
+
 	return nil
 }
 
@@ -321,16 +470,60 @@ func (v *ValidationVisitor) VisitSchema(ctx context.Context, schema *Schema) err
 		v.addError("warning", "potential circular reference detected", schema.LineNumber)
 	}
 
+	// Validate constraint keywords
+	if schema.Minimum != nil && schema.Maximum != nil && *schema.Minimum > *schema.Maximum {
+		v.addError("error", "schema minimum is greater than its maximum", schema.LineNumber)
+	}
+	if schema.MinLength != nil && schema.MaxLength != nil && *schema.MinLength > *schema.MaxLength {
+		v.addError("error", "schema minLength is greater than its maxLength", schema.LineNumber)
+	}
+	if schema.MinItems != nil && schema.MaxItems != nil && *schema.MinItems > *schema.MaxItems {
+		v.addError("error", "schema minItems is greater than its maxItems", schema.LineNumber)
+	}
+	if schema.MinProperties != nil && schema.MaxProperties != nil && *schema.MinProperties > *schema.MaxProperties {
+		v.addError("error", "schema minProperties is greater than its maxProperties", schema.LineNumber)
+	}
+	if schema.ReadOnly && schema.WriteOnly {
+		v.addError("error", "schema cannot be both readOnly and writeOnly", schema.LineNumber)
+	}
+	if schema.Pattern != "" {
+		if _, err := regexp.Compile(schema.Pattern); err != nil {
+			v.addError("warning", "schema pattern is not a valid regular expression: "+err.Error(), schema.LineNumber)
+		}
+	}
+	v.validateSunset("schema", schema.Deprecated)
+
+	// Schemas inferred from examples only describe the values that happened to be shown
+	if schema.Inferred {
+		v.errors = append(v.errors, errors.NewError(errors.ErrorTypeValidation, "schema was inferred from an example payload").
+			WithSeverity(errors.SeverityInfo).
+			AtLine(schema.LineNumber).
+			WithContext(v.currentPath).
+			WithSuggestion("Declare an explicit schema to document required fields, formats and constraints").
+			Build())
+	}
+
 	return nil
 }
 
+// validateSunset warns about deprecated elements whose sunset date has passed, as they were due to be removed
+func (v *ValidationVisitor) validateSunset(subject string, deprecation *Deprecation) {
+	if !sunsetPassed(deprecation, time.Now()) {
+		return
+	}
+	v.errors = append(v.errors, errors.NewWarning(errors.ErrorTypeValidation,
+		fmt.Sprintf("%s is past its sunset date %s", subject, deprecation.Sunset)).
+		AtLine(deprecation.LineNumber).
+		WithContext(v.currentPath).
+		WithSuggestion("Remove it, or move the sunset date if the removal was postponed").
+		Build())
+}
+
 func (v *ValidationVisitor) addError(level, message string, lineNumber int) {
-	// This assumes the ParseError struct and its builder in the errors package
-	// have been updated to handle a severity level.
 	v.errors = append(v.errors, errors.NewError(errors.ErrorTypeValidation, message).
+		WithSeverity(errors.Severity(level)).
 		AtLine(lineNumber).
 		WithContext(v.currentPath).
-		// WithSeverity(level). // Example of how it might be used
 		Build())
 }
 
@@ -354,6 +547,8 @@ type DocumentStatistics struct {
 	MaxSchemaDepth    int
 	HasFrontmatter    bool
 	TotalComponents   int
+	TotalWebhooks     int
+	TotalCallbacks    int
 	AveragePathLength float64
 }
 
@@ -370,6 +565,7 @@ func NewStatisticsVisitor() *StatisticsVisitor {
 func (v *StatisticsVisitor) VisitDocument(ctx context.Context, doc *Document) error {
 	v.Stats.TotalEndpoints = len(doc.Endpoints)
 	v.Stats.TotalComponents = len(doc.Components)
+	v.Stats.TotalWebhooks = len(doc.Webhooks)
 	v.Stats.HasFrontmatter = doc.Frontmatter != nil
 
 	// Calculate average path length
@@ -389,6 +585,11 @@ func (v *StatisticsVisitor) VisitEndpoint(ctx context.Context, endpoint *Endpoin
 	return nil
 }
 
+func (v *StatisticsVisitor) VisitCallback(ctx context.Context, callback *Callback) error {
+	v.Stats.TotalCallbacks++
+	return nil
+}
+
 func (v *StatisticsVisitor) VisitParameter(ctx context.Context, parameter *Parameter) error {
 	v.Stats.TotalParameters++
 	v.Stats.ParametersByType[parameter.Type]++
@@ -465,8 +666,8 @@ func (v *TransformVisitor) VisitEndpoint(ctx context.Context, endpoint *Endpoint
 // Helper functions for using visitors
 
 // ValidateDocument validates a document using the validation visitor
-func ValidateDocument(ctx context.Context, doc *Document, strictMode bool) []*errors.ParseError {
-	visitor := NewValidationVisitor(strictMode)
+func ValidateDocument(ctx context.Context, doc *Document, strictMode bool, opts ...ValidationOption) []*errors.ParseError {
+	visitor := NewValidationVisitor(strictMode, opts...)
 	if err := doc.Accept(ctx, visitor); err != nil {
 		// If the returned error is already a ParseError, add it directly.
 		if pe, ok := err.(*errors.ParseError); ok {
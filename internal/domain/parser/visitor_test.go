@@ -86,7 +86,7 @@ func TestValidationVisitor_VisitEndpoint(t *testing.T) {
 			expectedError: false,
 		},
 		{
-			name: "error with invalid HTTP method",
+			name: "success with invalid HTTP method left to the parser",
 			endpoint: &Endpoint{
 				Method:     "INVALID",
 				Path:       "/test",
@@ -135,6 +135,84 @@ func TestValidationVisitor_VisitEndpoint(t *testing.T) {
 	}
 }
 
+func TestValidationVisitor_PathParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		endpoint   *Endpoint
+		messages   []string
+		parameters []string
+	}{
+		{
+			name: "declared path parameters match the template",
+			endpoint: &Endpoint{Method: "GET", Path: "/users/{id}", LineNumber: 1, Parameters: []*Parameter{
+				{Name: "id", In: "path", Type: "integer", Required: true, LineNumber: 3},
+			}},
+			parameters: []string{"id"},
+		},
+		{
+			name:     "undeclared variables are reported without changing the endpoint",
+			endpoint: &Endpoint{Method: "GET", Path: "/users/{id}/keys/{keyId}", LineNumber: 1},
+			messages: []string{"path parameter 'id' is not declared", "path parameter 'keyId' is not declared"},
+		},
+		{
+			name: "implicit parameter is reported",
+			endpoint: &Endpoint{Method: "GET", Path: "/users/{id}", LineNumber: 1, Parameters: []*Parameter{
+				{Name: "id", In: "path", Type: "string", Required: true, Implicit: true, LineNumber: 1},
+			}},
+			messages:   []string{"path parameter 'id' is not declared, assuming a required string"},
+			parameters: []string{"id"},
+		},
+		{
+			name: "orphaned path parameter",
+			endpoint: &Endpoint{Method: "GET", Path: "/users", LineNumber: 1, Parameters: []*Parameter{
+				{Name: "id", In: "path", Type: "string", Required: true, LineNumber: 3},
+			}},
+			messages:   []string{"path parameter 'id' does not appear in the path /users"},
+			parameters: []string{"id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visitor := NewValidationVisitor(false)
+			assert.NoError(t, visitor.VisitEndpoint(context.Background(), tt.endpoint))
+
+			var messages []string
+			for _, err := range visitor.GetErrors() {
+				messages = append(messages, err.Message)
+			}
+			assert.Equal(t, tt.messages, messages)
+
+			var parameters []string
+			for _, parameter := range tt.endpoint.Parameters {
+				assert.Equal(t, "path", parameter.In)
+				assert.True(t, parameter.Required)
+				parameters = append(parameters, parameter.Name)
+			}
+			assert.Equal(t, tt.parameters, parameters)
+		})
+	}
+}
+
+func TestValidationVisitor_PathTemplateCollisions(t *testing.T) {
+	doc := &Document{
+		Endpoints: []*Endpoint{
+			{Method: "GET", Path: "/users/{id}", LineNumber: 1},
+			{Method: "GET", Path: "/users/{userId}", LineNumber: 5},
+			{Method: "DELETE", Path: "/users/{userId}", LineNumber: 9},
+		},
+	}
+
+	visitor := NewValidationVisitor(false)
+	assert.NoError(t, visitor.VisitDocument(context.Background(), doc))
+
+	errs := visitor.GetErrors()
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "path GET /users/{userId} collides with /users/{id} on line 1", errs[0].Message)
+		assert.Equal(t, 5, errs[0].LineNumber)
+	}
+}
+
 func TestValidationVisitor_VisitParameter(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -364,6 +442,7 @@ func TestHelper_ValidateDocument(t *testing.T) {
 		name          string
 		doc           *Document
 		strictMode    bool
+		options       []ValidationOption
 		expectedCount int
 	}{
 		{
@@ -381,7 +460,7 @@ func TestHelper_ValidateDocument(t *testing.T) {
 			expectedCount: 0,
 		},
 		{
-			name: "error with invalid HTTP method",
+			name: "invalid HTTP method is left to the parser",
 			doc: &Document{
 				Endpoints: []*Endpoint{
 					{
@@ -392,7 +471,7 @@ func TestHelper_ValidateDocument(t *testing.T) {
 				},
 			},
 			strictMode:    false,
-			expectedCount: 1,
+			expectedCount: 0,
 		},
 		{
 			name: "error with invalid path",
@@ -422,11 +501,38 @@ func TestHelper_ValidateDocument(t *testing.T) {
 			strictMode:    true,
 			expectedCount: 1,
 		},
+		{
+			name:          "error without endpoints",
+			doc:           &Document{},
+			strictMode:    false,
+			expectedCount: 1,
+		},
+		{
+			name:          "success without endpoints when they are not required",
+			doc:           &Document{},
+			strictMode:    true,
+			options:       []ValidationOption{WithEndpointsRequired(false)},
+			expectedCount: 0,
+		},
+		{
+			name: "success with only webhooks",
+			doc: &Document{
+				Webhooks: []*Webhook{
+					{
+						Name:       "order.created",
+						Operation:  &Endpoint{Method: "POST", LineNumber: 1},
+						LineNumber: 1,
+					},
+				},
+			},
+			strictMode:    false,
+			expectedCount: 0,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errors := ValidateDocument(context.Background(), tt.doc, tt.strictMode)
+			errors := ValidateDocument(context.Background(), tt.doc, tt.strictMode, tt.options...)
 			assert.Len(t, errors, tt.expectedCount)
 		})
 	}
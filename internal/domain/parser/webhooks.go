@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+// callbackHeadingShape is the expected shape of a callback heading, used in suggestions
+const callbackHeadingShape = "#### onPaymentCompleted: POST {$request.body#/callbackUrl}"
+
+var (
+	webhookTitlePattern  = regexp.MustCompile(`(?i)^webhook\s+(?:([A-Za-z]+)\s+)?([^\s/]+)$`)
+	callbackTitlePattern = regexp.MustCompile("^`?([A-Za-z_][\\w.-]*)`?\\s*:?\\s+([A-Za-z]+)\\s+`?(\\S+?)`?$")
+)
+
+// isWebhookSection reports whether a heading declares a webhook, e.g. "## WEBHOOK order.created"
+func isWebhookSection(sec *section) bool {
+	return sec.level == endpointHeadingLevel && webhookTitlePattern.MatchString(strings.TrimSpace(sec.title))
+}
+
+// isCallbacksSection reports whether an endpoint subsection title introduces its callbacks
+func isCallbacksSection(title string) bool {
+	return normalizeTitle(title) == "callbacks"
+}
+
+// parseWebhooks parses every "## WEBHOOK order.created" section.
+// Webhooks without explicit tags are tagged with their enclosing top-level heading, like endpoints.
+func (p *Parser) parseWebhooks(ctx context.Context, root *section) ([]*Webhook, []*errors.ParseError) {
+	var webhooks []*Webhook
+	var parseErrors []*errors.ParseError
+
+	var walk func(sec *section, group string)
+	walk = func(sec *section, group string) {
+		for _, child := range sec.children {
+			if ctx.Err() != nil {
+				return
+			}
+			if !isWebhookSection(child) {
+				if isTagGroupSection(child) {
+					walk(child, tagName(child.title))
+				} else {
+					walk(child, group)
+				}
+				continue
+			}
+
+			webhook, errs := p.parseWebhook(ctx, child)
+			parseErrors = append(parseErrors, errs...)
+			if webhook == nil {
+				continue
+			}
+			if webhook.Operation.Tags == nil && group != "" {
+				webhook.Operation.Tags = []string{group}
+			}
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	walk(root, "")
+
+	return webhooks, parseErrors
+}
+
+// parseWebhook parses a single webhook section. The method defaults to POST, so
+// "## WEBHOOK order.created" and "## WEBHOOK POST order.created" are equivalent.
+func (p *Parser) parseWebhook(ctx context.Context, sec *section) (*Webhook, []*errors.ParseError) {
+	matches := webhookTitlePattern.FindStringSubmatch(strings.TrimSpace(sec.title))
+
+	method := strings.ToUpper(matches[1])
+	if method == "" {
+		method = "POST"
+	}
+	if !isKnownHTTPMethod(method) {
+		return nil, []*errors.ParseError{errors.NewError(errors.ErrorTypeEndpoint,
+			fmt.Sprintf("unknown webhook method %q", matches[1])).
+			AtLine(sec.line).
+			InSource("webhook").
+			WithSuggestion("Use the form '## WEBHOOK order.created' or '## WEBHOOK PUT order.created'").
+			Build()}
+	}
+
+	if fenceLine := findUnterminatedFence(sec); fenceLine > 0 {
+		return nil, []*errors.ParseError{unterminatedFenceError(fenceLine, "webhook")}
+	}
+
+	operation := &Endpoint{
+		Method:     method,
+		Parameters: []*Parameter{},
+		Responses:  []*Response{},
+		LineNumber: sec.line,
+	}
+	parseErrors := p.parseOperation(ctx, operation, sec)
+	if operation.OperationID == "" {
+		operation.OperationID = deriveOperationID(method, matches[2], p.config.OperationIDStyle)
+	}
+
+	return &Webhook{Name: matches[2], Operation: operation, LineNumber: sec.line}, parseErrors
+}
+
+// parseCallbacksSection parses the "#### onPaymentCompleted: POST {$request.body#/callbackUrl}"
+// headings of a "### Callbacks" section. Callback operations only get an operation ID when one is set explicitly.
+func (p *Parser) parseCallbacksSection(ctx context.Context, sec *section) ([]*Callback, []*errors.ParseError) {
+	var callbacks []*Callback
+	var parseErrors []*errors.ParseError
+
+	for _, child := range sec.children {
+		matches := callbackTitlePattern.FindStringSubmatch(strings.TrimSpace(child.title))
+		if matches == nil || !isKnownHTTPMethod(strings.ToUpper(matches[2])) {
+			parseErrors = append(parseErrors, errors.NewError(errors.ErrorTypeSyntax,
+				"callback heading must name the callback, a method and a URL expression: "+child.title).
+				AtLine(child.line).
+				InSource("callback").
+				WithSuggestion("Use the form '"+callbackHeadingShape+"'").
+				Build())
+			continue
+		}
+
+		if fenceLine := findUnterminatedFence(child); fenceLine > 0 {
+			parseErrors = append(parseErrors, unterminatedFenceError(fenceLine, "callback"))
+			continue
+		}
+
+		operation := &Endpoint{
+			Method:     strings.ToUpper(matches[2]),
+			Parameters: []*Parameter{},
+			Responses:  []*Response{},
+			LineNumber: child.line,
+		}
+		parseErrors = append(parseErrors, p.parseOperation(ctx, operation, child)...)
+
+		callbacks = append(callbacks, &Callback{
+			Name:       matches[1],
+			Expression: matches[3],
+			Operation:  operation,
+			LineNumber: child.line,
+		})
+	}
+
+	return callbacks, parseErrors
+}
+
+// documentOperation is an operation of the document together with a label naming it in messages
+type documentOperation struct {
+	label     string
+	operation *Endpoint
+}
+
+// documentOperations lists the endpoints, their callbacks and the webhooks of a document
+func documentOperations(doc *Document) []documentOperation {
+	var operations []documentOperation
+
+	var addEndpoint func(endpoint *Endpoint, label string)
+	addEndpoint = func(endpoint *Endpoint, label string) {
+		operations = append(operations, documentOperation{label: label, operation: endpoint})
+		for _, callback := range endpoint.Callbacks {
+			addEndpoint(callback.Operation, fmt.Sprintf("callback %s %s", callback.Name, callback.Operation.Method))
+		}
+	}
+
+	for _, endpoint := range doc.Endpoints {
+		addEndpoint(endpoint, endpoint.Method+" "+endpoint.Path)
+	}
+	for _, webhook := range doc.Webhooks {
+		addEndpoint(webhook.Operation, fmt.Sprintf("webhook %s %s", webhook.Operation.Method, webhook.Name))
+	}
+
+	return operations
+}
+
+// isKnownHTTPMethod reports whether method is an upper-case HTTP method
+func isKnownHTTPMethod(method string) bool {
+	for _, known := range knownHTTPMethods {
+		if method == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/pkg/errors"
+)
+
+func TestParser_ParseWebhooks(t *testing.T) {
+	content := "# Events\n\n" +
+		"## WEBHOOK order.created\n\n" +
+		"Sent when an order is created.\n\n" +
+		"### Request Body\n\n" +
+		"| Name | Type | Required |\n" +
+		"| --- | --- | --- |\n" +
+		"| id | string | yes |\n\n" +
+		"### Responses\n\n" +
+		"- **200** - Received\n\n" +
+		"## WEBHOOK DELETE order.created\n\n" +
+		"**Operation ID:** orderDeleted\n\n" +
+		"## WEBHOOK FETCH order.updated\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Errors, 1)
+	assert.Equal(t, errors.ErrorTypeEndpoint, doc.Errors[0].Type)
+	assert.Equal(t, "unknown webhook method \"FETCH\"", doc.Errors[0].Message)
+	assert.Equal(t, 21, doc.Errors[0].LineNumber)
+
+	require.Len(t, doc.Webhooks, 2)
+	created := doc.Webhooks[0]
+	assert.Equal(t, "order.created", created.Name)
+	assert.Equal(t, 3, created.LineNumber)
+	assert.Equal(t, "POST", created.Operation.Method)
+	assert.Equal(t, "postOrderCreated", created.Operation.OperationID)
+	assert.Equal(t, "Sent when an order is created.", created.Operation.Summary)
+	assert.Equal(t, []string{"Events"}, created.Operation.Tags)
+	require.NotNil(t, created.Operation.RequestBody)
+	assert.Equal(t, []string{"id"}, created.Operation.RequestBody.Content[defaultMediaType].Required)
+	require.Len(t, created.Operation.Responses, 1)
+	assert.Equal(t, "200", created.Operation.Responses[0].StatusCode)
+
+	deleted := doc.Webhooks[1]
+	assert.Equal(t, "DELETE", deleted.Operation.Method)
+	assert.Equal(t, "orderDeleted", deleted.Operation.OperationID)
+
+	assert.Empty(t, doc.Endpoints)
+	require.Len(t, doc.Tags, 1)
+	assert.Equal(t, "Events", doc.Tags[0].Name)
+}
+
+func TestParser_ParseCallbacks(t *testing.T) {
+	content := "## POST /payments\n\n" +
+		"### Callbacks\n\n" +
+		"#### onPaid: POST {$request.body#/callbackUrl}\n\n" +
+		"Payment completed.\n\n" +
+		"**Request Body:**\n\n" +
+		"| Name | Type |\n" +
+		"| --- | --- |\n" +
+		"| paymentId | string |\n\n" +
+		"##### Responses\n\n" +
+		"- **204** - Acknowledged\n\n" +
+		"#### notify the client\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Errors, 1)
+	assert.Equal(t, errors.ErrorTypeSyntax, doc.Errors[0].Type)
+	assert.Equal(t, "callback heading must name the callback, a method and a URL expression: notify the client", doc.Errors[0].Message)
+	assert.Equal(t, 19, doc.Errors[0].LineNumber)
+
+	require.Len(t, doc.Endpoints, 1)
+	callbacks := doc.Endpoints[0].Callbacks
+	require.Len(t, callbacks, 1)
+
+	callback := callbacks[0]
+	assert.Equal(t, "onPaid", callback.Name)
+	assert.Equal(t, "{$request.body#/callbackUrl}", callback.Expression)
+	assert.Equal(t, 5, callback.LineNumber)
+	assert.Equal(t, "POST", callback.Operation.Method)
+	assert.Empty(t, callback.Operation.OperationID)
+	assert.Equal(t, "Payment completed.", callback.Operation.Summary)
+	require.NotNil(t, callback.Operation.RequestBody)
+	assert.Contains(t, callback.Operation.RequestBody.Content[defaultMediaType].Properties, "paymentId")
+	require.Len(t, callback.Operation.Responses, 1)
+	assert.Equal(t, "204", callback.Operation.Responses[0].StatusCode)
+
+	stats := GetDocumentStatistics(context.Background(), doc)
+	assert.Equal(t, 1, stats.TotalCallbacks)
+}
+
+func TestParser_DuplicateWebhookOperationID(t *testing.T) {
+	content := "## POST /orders\n\n" +
+		"**Operation ID:** orderCreated\n\n" +
+		"## WEBHOOK order.created\n\n" +
+		"**Operation ID:** orderCreated\n"
+
+	doc, err := New().Parse(content)
+	require.NoError(t, err)
+
+	require.Len(t, doc.Errors, 1)
+	assert.Equal(t, "duplicate operationId 'orderCreated', already used by POST /orders on line 1", doc.Errors[0].Message)
+	assert.Equal(t, 5, doc.Errors[0].LineNumber)
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// extensionPrefix is the prefix OpenAPI requires of vendor extension keys
+const extensionPrefix = "x-"
+
+// nameMapKeys lists the fields whose values map user-chosen names to objects. An "x-" key directly
+// inside them is a name, such as a property called "x-count", not an extension.
+var nameMapKeys = map[string]bool{
+	"properties":        true,
+	"patternProperties": true,
+	"dependentSchemas":  true,
+	"$defs":             true,
+	"definitions":       true,
+	"schemas":           true,
+	"parameters":        true,
+	"requestBodies":     true,
+	"headers":           true,
+	"securitySchemes":   true,
+	"links":             true,
+	"callbacks":         true,
+	"pathItems":         true,
+	"webhooks":          true,
+	"examples":          true,
+	"content":           true,
+	"encoding":          true,
+	"scopes":            true,
+	"variables":         true,
+	"mapping":           true,
+}
+
+// literalKeys lists the fields holding literal values, whose keys are data rather than OpenAPI fields
+var literalKeys = map[string]bool{
+	"example": true,
+	"default": true,
+	"enum":    true,
+	"const":   true,
+	"value":   true,
+}
+
+// extensionLocation is a vendor extension found in a specification
+type extensionLocation struct {
+	name    string
+	pointer string // JSON pointer of the object holding the extension
+}
+
+// findExtensions returns the vendor extensions of every object in a parsed specification, in document order
+func findExtensions(node *yaml.Node) []extensionLocation {
+	var found []extensionLocation
+	var walk func(node *yaml.Node, pointer string, names bool)
+	walk = func(node *yaml.Node, pointer string, names bool) {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				walk(child, pointer, false)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i].Value, node.Content[i+1]
+				if !names && strings.HasPrefix(key, extensionPrefix) {
+					// Extension values are free-form, so nothing beneath them is checked
+					found = append(found, extensionLocation{name: key, pointer: pointer})
+					continue
+				}
+				if !names && literalKeys[key] {
+					continue
+				}
+				walk(value, pointer+"/"+escapePointerToken(key), !names && nameMapKeys[key])
+			}
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				walk(item, pointer+"/"+strconv.Itoa(i), false)
+			}
+		}
+	}
+	walk(node, "#", false)
+	return found
+}
+
+// escapePointerToken escapes a key for use in a JSON pointer
+func escapePointerToken(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
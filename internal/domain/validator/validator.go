@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds validator configuration
@@ -11,7 +13,7 @@ type Config struct {
 	StrictMode         bool
 	ValidateExamples   bool
 	CheckBestPractices bool
-	AllowExtensions    bool
+	AllowExtensions    bool // when false, each vendor extension is reported; as an error in strict mode
 }
 
 // OpenAPIValidator validates OpenAPI specifications
@@ -73,10 +75,19 @@ func (v *OpenAPIValidator) Validate(ctx context.Context, content string) (*Valid
 		}
 	}
 
-	// Strict mode checks
-	if v.config.StrictMode {
-		if strings.Contains(content, "x-") && !v.config.AllowExtensions {
-			warnings = append(warnings, "OpenAPI extensions (x-*) found in strict mode")
+	// Extension checks look at the keys of each object, so "x-" in names and values is not an extension
+	if !v.config.AllowExtensions {
+		var spec yaml.Node
+		if err := yaml.Unmarshal([]byte(content), &spec); err != nil {
+			errors = append(errors, fmt.Sprintf("Content is not valid YAML or JSON: %v", err))
+		}
+		for _, extension := range findExtensions(&spec) {
+			message := fmt.Sprintf("Extension '%s' at %s is not allowed", extension.name, extension.pointer)
+			if v.config.StrictMode {
+				errors = append(errors, message)
+			} else {
+				warnings = append(warnings, message)
+			}
 		}
 	}
 
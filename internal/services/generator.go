@@ -13,11 +13,19 @@ import (
 
 // GenerationResult represents the result of OpenAPI generation
 type GenerationResult struct {
-	Content  string             `json:"content"`
-	Format   string             `json:"format"`
-	Metadata GenerationMetadata `json:"metadata"`
-	Warnings []string           `json:"warnings,omitempty"`
-	Errors   []string           `json:"errors,omitempty"`
+	Content  string                    `json:"content"`
+	Format   string                    `json:"format"`
+	Metadata GenerationMetadata        `json:"metadata"`
+	Warnings []string                  `json:"warnings,omitempty"`
+	Errors   []string                  `json:"errors,omitempty"`
+	Hoisted  []generator.HoistedSchema `json:"hoisted,omitempty"` // inline schemas moved into components
+}
+
+// SchemaExportResult represents the result of a JSON Schema export
+type SchemaExportResult struct {
+	Files    []generator.JSONSchemaFile `json:"files"`
+	Warnings []string                   `json:"warnings,omitempty"`
+	Errors   []string                   `json:"errors,omitempty"`
 }
 
 // GenerationMetadata contains metadata about the generation process
@@ -48,6 +56,7 @@ func NewGenerator(cfg *config.ExtendedConfig, logger *slog.Logger) *Generator {
 		parser.WithValidationLevel(cfg.ValidationLevel),
 		parser.WithRequireExamples(cfg.RequireExamples),
 		parser.WithMaxNestingDepth(cfg.MaxNestingDepth),
+		parser.WithOperationIDStyle(cfg.OperationIDStyle),
 		parser.WithInitialSliceCapacity(cfg.InitialSliceCapacity),
 	)
 
@@ -58,6 +67,9 @@ func NewGenerator(cfg *config.ExtendedConfig, logger *slog.Logger) *Generator {
 		IncludeExamples: true,
 		ValidateOutput:  true,
 		StrictMode:      cfg.StrictMode,
+		PathOrder:       cfg.PathOrder,
+		HoistSchemas:    cfg.HoistSchemas,
+		Target:          cfg.Target,
 	})
 
 	return &Generator{
@@ -77,23 +89,10 @@ func (g *Generator) Generate(ctx context.Context, content string, format string)
 		"format", format,
 	)
 
-	// Parse the markdown content
-	doc, err := g.parser.ParseWithContext(ctx, content)
+	// Parse and validate the markdown content
+	doc, parseErrors, parseWarnings, err := g.parse(ctx, content)
 	if err != nil {
-		g.logger.ErrorContext(ctx, "Failed to parse markdown", "error", err)
-		return nil, fmt.Errorf("failed to parse markdown: %w", err)
-	}
-
-	// Check for parse errors
-	var parseErrors []string
-	var parseWarnings []string
-
-	for _, parseErr := range doc.Errors {
-		if parseErr.IsError() {
-			parseErrors = append(parseErrors, parseErr.Error())
-		} else if parseErr.IsWarning() {
-			parseWarnings = append(parseWarnings, parseErr.Error())
-		}
+		return nil, err
 	}
 
 	// If there are fatal errors and we're in strict mode, return early
@@ -112,12 +111,15 @@ func (g *Generator) Generate(ctx context.Context, content string, format string)
 	}
 
 	// Generate OpenAPI specification
-	spec, err := g.generator.Generate(ctx, doc, format)
+	spec, report, err := g.generator.GenerateWithReport(ctx, doc, format)
 	if err != nil {
 		g.logger.ErrorContext(ctx, "Failed to generate OpenAPI spec", "error", err)
 		return nil, fmt.Errorf("failed to generate OpenAPI spec: %w", err)
 	}
 
+	// Down-conversion warnings name what the target cannot represent
+	parseWarnings = append(parseWarnings, report.Warnings...)
+
 	processingTime := time.Since(startTime)
 
 	result := &GenerationResult{
@@ -125,6 +127,7 @@ func (g *Generator) Generate(ctx context.Context, content string, format string)
 		Format:   format,
 		Warnings: parseWarnings,
 		Errors:   parseErrors,
+		Hoisted:  report.Hoisted,
 		Metadata: GenerationMetadata{
 			ProcessingTimeMs: int(processingTime.Milliseconds()),
 			InputSizeBytes:   len(content),
@@ -141,11 +144,79 @@ func (g *Generator) Generate(ctx context.Context, content string, format string)
 		"output_size", result.Metadata.OutputSizeBytes,
 		"warnings", len(parseWarnings),
 		"errors", len(parseErrors),
+		"hoisted_schemas", len(report.Hoisted),
 	)
 
 	return result, nil
 }
 
+// ExportJSONSchemas exports the components of Markdown content as standalone JSON Schema documents
+func (g *Generator) ExportJSONSchemas(ctx context.Context, content string, exportConfig generator.JSONSchemaConfig) (*SchemaExportResult, error) {
+	g.logger.InfoContext(ctx, "Starting JSON Schema export", "input_size", len(content))
+
+	// The export only needs the components, so documents without endpoints are fine
+	doc, parseErrors, parseWarnings, err := g.parse(ctx, content, parser.WithEndpointsRequired(false))
+	if err != nil {
+		return nil, err
+	}
+	if len(parseErrors) > 0 && g.config.StrictMode {
+		return &SchemaExportResult{
+			Errors:   parseErrors,
+			Warnings: parseWarnings,
+		}, fmt.Errorf("parsing failed with %d errors", len(parseErrors))
+	}
+
+	files, err := g.generator.GenerateJSONSchemas(ctx, doc, exportConfig)
+	if err != nil {
+		g.logger.ErrorContext(ctx, "Failed to export JSON Schemas", "error", err)
+		return nil, fmt.Errorf("failed to export JSON Schemas: %w", err)
+	}
+
+	g.logger.InfoContext(ctx, "JSON Schema export completed",
+		"files", len(files),
+		"warnings", len(parseWarnings),
+		"errors", len(parseErrors),
+	)
+
+	return &SchemaExportResult{
+		Files:    files,
+		Warnings: parseWarnings,
+		Errors:   parseErrors,
+	}, nil
+}
+
+// parse parses Markdown content and validates the document with the given options, splitting the findings
+// into errors and warnings
+func (g *Generator) parse(ctx context.Context, content string, opts ...parser.ValidationOption) (*parser.Document, []string, []string, error) {
+	doc, err := g.parser.ParseWithContext(ctx, content)
+	if err != nil {
+		g.logger.ErrorContext(ctx, "Failed to parse markdown", "error", err)
+		return nil, nil, nil, fmt.Errorf("failed to parse markdown: %w", err)
+	}
+
+	var parseErrors []string
+	var parseWarnings []string
+
+	for _, parseErr := range doc.Errors {
+		if parseErr.IsError() {
+			parseErrors = append(parseErrors, parseErr.Error())
+		} else if parseErr.IsWarning() {
+			parseWarnings = append(parseWarnings, parseErr.Error())
+		}
+	}
+
+	// Structural validation rules
+	for _, validationErr := range parser.ValidateDocument(ctx, doc, g.config.StrictMode, opts...) {
+		if validationErr.IsError() {
+			parseErrors = append(parseErrors, validationErr.Error())
+		} else if validationErr.IsWarning() {
+			parseWarnings = append(parseWarnings, validationErr.Error())
+		}
+	}
+
+	return doc, parseErrors, parseWarnings, nil
+}
+
 // GenerateFromFile generates an OpenAPI specification from a Markdown file
 func (g *Generator) GenerateFromFile(ctx context.Context, filename string, format string) (*GenerationResult, error) {
 	g.logger.InfoContext(ctx, "Generating from file", "filename", filename)
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukhera/APIWeaver/internal/config"
+	"github.com/sukhera/APIWeaver/internal/domain/generator"
+)
+
+// newTestGenerator creates a Generator service with the default configuration and a silent logger
+func newTestGenerator(strict bool, methods ...string) *Generator {
+	cfg := &config.ExtendedConfig{Config: config.Default()}
+	cfg.StrictMode = strict
+	if len(methods) > 0 {
+		cfg.AllowedMethods = methods
+	}
+	return NewGenerator(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestGenerator_ExportJSONSchemasWithoutEndpoints(t *testing.T) {
+	content := "---\n" +
+		"title: Models\n" +
+		"version: 1.0.0\n" +
+		"---\n\n" +
+		"# Components\n\n" +
+		"## Schema: User\n\n" +
+		"```yaml\n" +
+		"type: object\n" +
+		"properties:\n" +
+		"  id:\n" +
+		"    type: string\n" +
+		"```\n"
+
+	result, err := newTestGenerator(true).ExportJSONSchemas(context.Background(), content, generator.JSONSchemaConfig{})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "User", result.Files[0].Component)
+}
+
+func TestGenerator_GenerateRequiresEndpointsOrWebhooks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "components only",
+			content: "# Components\n\n## Schema: User\n\n```yaml\ntype: string\n```\n",
+			wantErr: true,
+		},
+		{
+			name:    "webhooks only",
+			content: "## WEBHOOK order.created\n\nSent when an order is created.\n\n### Responses\n\n- **200** - Received\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestGenerator(true).Generate(context.Background(), tt.content, "json")
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, result.Errors, "document must contain at least one endpoint")
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, result.Errors)
+		})
+	}
+}
+
+func TestGenerator_MethodsFollowTheConfiguration(t *testing.T) {
+	content := "## TRACE /debug\n\nEcho the request.\n\n### Responses\n\n- **200** - Echoed\n"
+
+	tests := []struct {
+		name     string
+		methods  []string
+		expected []string
+	}{
+		{
+			name:     "default methods report the method once",
+			expected: []string{"line 1 Invalid HTTP method: TRACE (suggestion: Use one of: GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS)"},
+		},
+		{
+			name:    "configured methods allow it",
+			methods: []string{"GET", "TRACE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestGenerator(false, tt.methods...).Generate(context.Background(), content, "json")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Errors)
+		})
+	}
+}
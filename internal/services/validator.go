@@ -47,6 +47,7 @@ func NewValidator(cfg *config.ExtendedConfig, logger *slog.Logger) *Validator {
 		parser.WithValidationLevel(cfg.ValidationLevel),
 		parser.WithRequireExamples(cfg.RequireExamples),
 		parser.WithMaxNestingDepth(cfg.MaxNestingDepth),
+		parser.WithOperationIDStyle(cfg.OperationIDStyle),
 		parser.WithInitialSliceCapacity(cfg.InitialSliceCapacity),
 	)
 
@@ -135,10 +136,26 @@ func (v *Validator) validateMarkdown(ctx context.Context, content string) (*Vali
 		}
 	}
 
-	// Additional validation rules
-	if len(doc.Endpoints) == 0 {
+	// Report endpoints the parser had to skip while recovering from errors
+	for _, skipped := range doc.Skipped {
+		warnings = append(warnings, fmt.Sprintf("Endpoint %q at line %d was skipped: %s", skipped.Heading, skipped.LineNumber, skipped.Reason))
+	}
+
+	// Additional validation rules. A document without endpoints is only worth a warning here, so the
+	// structural rules do not require them.
+	if len(doc.Endpoints) == 0 && len(doc.Webhooks) == 0 {
 		warnings = append(warnings, "No endpoints found in the document")
 	}
+	for _, validationErr := range parser.ValidateDocument(ctx, doc, v.config.StrictMode, parser.WithEndpointsRequired(false)) {
+		switch {
+		case validationErr.IsError():
+			errors = append(errors, validationErr.Error())
+		case validationErr.IsWarning():
+			warnings = append(warnings, validationErr.Error())
+		default:
+			suggestions = append(suggestions, validationErr.Error())
+		}
+	}
 
 	if doc.Frontmatter == nil {
 		suggestions = append(suggestions, "Consider adding YAML frontmatter with API metadata")
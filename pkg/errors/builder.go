@@ -44,6 +44,12 @@ func (b *ErrorBuilder) WithCode(code string) *ErrorBuilder {
 	return b
 }
 
+// WithSeverity overrides the severity set by the constructor
+func (b *ErrorBuilder) WithSeverity(severity Severity) *ErrorBuilder {
+	b.error.Severity = severity
+	return b
+}
+
 // AtLine sets the line number
 func (b *ErrorBuilder) AtLine(line int) *ErrorBuilder {
 	b.error.LineNumber = line